@@ -0,0 +1,11 @@
+# glesys_cloudconfig datasource
+data "glesys_cloudconfig" "www" {
+  cloudconfig = file("cloud-config.yaml.mustache")
+  cloudconfigparams = {
+    hostname = "www1"
+  }
+}
+
+output "www-cloudconfig" {
+  value = data.glesys_cloudconfig.www.rendered
+}
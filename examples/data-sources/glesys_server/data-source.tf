@@ -0,0 +1,8 @@
+# glesys_server datasource
+data "glesys_server" "bastion" {
+  hostname = "bastion1"
+}
+
+output "bastion-ip" {
+  value = data.glesys_server.bastion.ipv4_address
+}
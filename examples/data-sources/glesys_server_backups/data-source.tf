@@ -0,0 +1,17 @@
+# glesys_server_backups datasource
+data "glesys_server_backups" "production" {
+  serverid = glesys_server.production.id
+}
+
+# Restore the latest backup of production to a new server
+resource "glesys_server" "test" {
+  hostname         = "test"
+  platform         = "KVM"
+  datacenter       = "Falkenberg"
+  bandwidth        = 100
+  cpu              = 2
+  memory           = 2048
+  storage          = 20
+  source_server_id = glesys_server.production.id
+  source_backup_id = data.glesys_server_backups.production.backups[0].id
+}
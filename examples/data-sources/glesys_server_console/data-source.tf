@@ -0,0 +1,9 @@
+# glesys_server_console datasource
+data "glesys_server_console" "web" {
+  serverid = glesys_server.web.id
+}
+
+output "console_url" {
+  value     = data.glesys_server_console.web.url
+  sensitive = true
+}
@@ -0,0 +1,8 @@
+# glesys_server_isos datasource
+data "glesys_server_isos" "rescue" {
+  serverid = glesys_server.vmware.id
+}
+
+output "isofiles" {
+  value = data.glesys_server_isos.rescue.isofiles
+}
@@ -0,0 +1,8 @@
+# glesys_server_status datasource
+data "glesys_server_status" "web" {
+  serverid = glesys_server.web.id
+}
+
+output "transfer_used" {
+  value = "${data.glesys_server_status.web.transfer[0].usage} of ${data.glesys_server_status.web.transfer[0].max} ${data.glesys_server_status.web.transfer[0].unit}"
+}
@@ -0,0 +1,16 @@
+# glesys_servers datasource
+data "glesys_servers" "web" {
+  datacenter     = "Falkenberg"
+  hostname_regex = "^web"
+}
+
+resource "glesys_loadbalancer_target" "web" {
+  for_each = { for s in data.glesys_servers.web.servers : s.hostname => s }
+
+  loadbalancerid = glesys_loadbalancer.lb.id
+  backend        = glesys_loadbalancer_backend.web.name
+  name           = each.key
+  targetip       = each.value.ipv4_address
+  port           = 80
+  weight         = 5
+}
@@ -0,0 +1,11 @@
+# glesys_templates datasource
+data "glesys_templates" "debian" {
+  platform    = "KVM"
+  name_regex  = "^Debian"
+  most_recent = true
+}
+
+resource "glesys_server" "www" {
+  # ...
+  template = data.glesys_templates.debian.templates[0].id
+}
@@ -0,0 +1,2 @@
+# LoadBalancer import.
+$ terraform import glesys_loadbalancer.example lb123456
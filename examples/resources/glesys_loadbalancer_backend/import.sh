@@ -0,0 +1,2 @@
+# LoadBalancer Backend import.
+$ terraform import glesys_loadbalancer_backend.web lb123456/web
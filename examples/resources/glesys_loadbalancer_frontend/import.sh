@@ -0,0 +1,2 @@
+# LoadBalancer Frontend import.
+$ terraform import glesys_loadbalancer_frontend.http lb123456/http
@@ -0,0 +1,2 @@
+# LoadBalancer Target import. <loadbalancerid>/<backend>/<target>
+$ terraform import glesys_loadbalancer_target.web1 lb123456/web/web1
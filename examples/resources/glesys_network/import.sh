@@ -0,0 +1,2 @@
+# Network import.
+$ terraform import glesys_network.example vl123456
@@ -0,0 +1,3 @@
+# ObjectStorage credential import. <instanceid>,<credentialid>
+# The secret key is only available when the credential is created, and is empty after import.
+$ terraform import glesys_objectstorage_credential.example os-ab123,aaaaaa-bbbb-cccc-ddddddddd
@@ -1,2 +1,5 @@
 # ServerDisk import.
 $ terraform import glesys_server_disk.data wps123456,aaaaaa-bbbb-cccc-ddddddddd
+
+# ServerDisk import by disk ID only, the server is looked up in the project.
+$ terraform import glesys_server_disk.data aaaaaa-bbbb-cccc-ddddddddd
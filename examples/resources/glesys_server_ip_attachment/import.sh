@@ -0,0 +1,2 @@
+# IP attachment import.
+$ terraform import glesys_server_ip_attachment.mail kvm123456,192.0.2.10
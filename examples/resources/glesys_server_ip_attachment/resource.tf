@@ -0,0 +1,10 @@
+resource "glesys_ip" "mail" {
+  datacenter = "Falkenberg"
+  platform   = "KVM"
+  version    = 4
+}
+
+resource "glesys_server_ip_attachment" "mail" {
+  serverid = glesys_server.mail.id
+  address  = glesys_ip.mail.address
+}
@@ -0,0 +1,2 @@
+# Server ISO import.
+$ terraform import glesys_server_iso.rescue wps123456
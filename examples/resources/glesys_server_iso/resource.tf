@@ -0,0 +1,4 @@
+resource "glesys_server_iso" "rescue" {
+  serverid = glesys_server.vmware.id
+  isofile  = "systemrescue-11.00-amd64.iso"
+}
@@ -0,0 +1,2 @@
+# Server limits import.
+$ terraform import glesys_server_limits.web kvm123456
@@ -0,0 +1,4 @@
+resource "glesys_server_limits" "web" {
+  serverid       = glesys_server.web.id
+  reset_exceeded = true
+}
@@ -0,0 +1,352 @@
+package glesys
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/glesys/glesys-go/v8"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+)
+
+// apiClient is the provider meta passed to all resources and data sources. It
+// embeds the glesys-go client, and adds the API calls glesys-go doesn't have
+// along with data cached for the lifetime of the provider instance.
+type apiClient struct {
+	*glesys.Client
+
+	userID     string
+	token      string
+	userAgent  string
+	httpClient *http.Client
+
+	// costWarningThreshold is the monthly cost above which a warning is
+	// shown for a resource, zero disables the warning.
+	costWarningThreshold float64
+
+	// waitPollInterval makes the state waiters poll at this interval without
+	// an initial delay. It is only set in tests, as the fake API changes state
+	// instantly.
+	waitPollInterval time.Duration
+
+	mu               sync.Mutex
+	allowedArguments map[string]serverAllowedArguments
+	templates        map[string][]serverTemplate
+}
+
+// stateChangeConf sets the Delay and MinTimeout of a state waiter, or polls at
+// waitPollInterval when set.
+func (c *apiClient) stateChangeConf(conf *retry.StateChangeConf, delay time.Duration, minTimeout time.Duration) *retry.StateChangeConf {
+	if c.waitPollInterval > 0 {
+		conf.MinTimeout = c.waitPollInterval
+		conf.PollInterval = c.waitPollInterval
+		return conf
+	}
+	conf.Delay = delay
+	conf.MinTimeout = minTimeout
+	return conf
+}
+
+// post calls an API endpoint that isn't available in glesys-go. Requests and
+// errors are handled the same way as in glesys-go, so that errors can be
+// inspected with parseAPIError.
+func (c *apiClient) post(ctx context.Context, path string, v interface{}, params interface{}) error {
+	u, err := url.Parse(path)
+	if err != nil {
+		return err
+	}
+	u = c.BaseURL.ResolveReference(u)
+
+	body := new(bytes.Buffer)
+	if params != nil {
+		if err := json.NewEncoder(body).Encode(params); err != nil {
+			return err
+		}
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, u.String(), body)
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("User-Agent", c.userAgent)
+	request.SetBasicAuth(c.userID, c.token)
+
+	response, err := c.httpClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	data, err := io.ReadAll(response.Body)
+	if err != nil {
+		return err
+	}
+
+	if response.StatusCode != http.StatusOK {
+		status := struct {
+			Response struct {
+				Status struct {
+					Text string `json:"text"`
+				} `json:"status"`
+			} `json:"response"`
+		}{}
+		if err := json.Unmarshal(data, &status); err != nil {
+			return err
+		}
+		return fmt.Errorf("Request failed with HTTP error: %v (%v)", response.StatusCode, strings.TrimSpace(status.Response.Status.Text))
+	}
+
+	if v == nil {
+		return nil
+	}
+	return json.Unmarshal(data, v)
+}
+
+// serverAllowedArguments are the values accepted by server/create on one
+// platform, keyed by argument name, e.g. "cpucores" or "datacenter".
+type serverAllowedArguments map[string][]string
+
+// serverAllowedArguments returns the allowed arguments for platform, or nil if
+// the platform isn't supported. The API is only called once per provider
+// instance.
+func (c *apiClient) serverAllowedArguments(ctx context.Context, platform string) (serverAllowedArguments, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.allowedArguments == nil {
+		data := struct {
+			Response struct {
+				ArgumentsList map[string]map[string]json.RawMessage `json:"argumentslist"`
+			} `json:"response"`
+		}{}
+		if err := c.post(ctx, "server/allowedarguments", &data, nil); err != nil {
+			return nil, err
+		}
+
+		c.allowedArguments = map[string]serverAllowedArguments{}
+		for p, arguments := range data.Response.ArgumentsList {
+			allowed := serverAllowedArguments{}
+			for name, raw := range arguments {
+				if values, ok := parseAllowedValues(raw); ok {
+					allowed[name] = values
+				}
+			}
+			c.allowedArguments[strings.ToLower(p)] = allowed
+		}
+	}
+
+	return c.allowedArguments[strings.ToLower(platform)], nil
+}
+
+// serverPlatforms returns the platforms listed by server/allowedarguments.
+func (c *apiClient) serverPlatforms() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var platforms []string
+	for p := range c.allowedArguments {
+		platforms = append(platforms, p)
+	}
+	sort.Strings(platforms)
+	return platforms
+}
+
+// parseAllowedValues reads a list of allowed values. Values are returned as
+// strings, so that numbers and names can be compared the same way. Arguments
+// that aren't a plain list, like ranges, are ignored.
+func parseAllowedValues(raw json.RawMessage) ([]string, bool) {
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+
+	var list []interface{}
+	if err := decoder.Decode(&list); err != nil {
+		return nil, false
+	}
+
+	values := make([]string, 0, len(list))
+	for _, v := range list {
+		switch v := v.(type) {
+		case string:
+			values = append(values, v)
+		case json.Number:
+			values = append(values, v.String())
+		default:
+			return nil, false
+		}
+	}
+	return values, true
+}
+
+// serverTemplate is a template as listed by server/templates. glesys-go
+// leaves out the tags currently pointing at the template.
+type serverTemplate struct {
+	glesys.ServerPlatformTemplateDetails
+	CurrentTags []string `json:"currenttags"`
+}
+
+// serverTemplates returns the templates of all platforms, keyed by platform.
+// The API is only called once per provider instance.
+func (c *apiClient) serverTemplates(ctx context.Context) (map[string][]serverTemplate, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.templates == nil {
+		data := struct {
+			Response struct {
+				Templates map[string][]serverTemplate `json:"templates"`
+			} `json:"response"`
+		}{}
+		if err := c.post(ctx, "server/templates", &data, nil); err != nil {
+			return nil, err
+		}
+		c.templates = data.Response.Templates
+	}
+
+	return c.templates, nil
+}
+
+// addServerIP adds the reserved IP address to a server.
+func (c *apiClient) addServerIP(ctx context.Context, serverID string, address string) error {
+	return c.post(ctx, "ip/add", nil, struct {
+		Address  string `json:"ipaddress"`
+		ServerID string `json:"serverid"`
+	}{address, serverID})
+}
+
+// removeServerIP removes the IP address from its server. The IP is only
+// released from the project when release is set.
+func (c *apiClient) removeServerIP(ctx context.Context, address string, release bool) error {
+	return c.post(ctx, "ip/remove", nil, struct {
+		Address string `json:"ipaddress"`
+		Release bool   `json:"release"`
+	}{address, release})
+}
+
+// serverBackup is a backup image of a KVM server, as listed by
+// server/listbackups.
+type serverBackup struct {
+	ID        string `json:"id"`
+	CreatedAt string `json:"createdat"`
+}
+
+// listServerBackups returns the backup images of a KVM server.
+func (c *apiClient) listServerBackups(ctx context.Context, serverID string) ([]serverBackup, error) {
+	data := struct {
+		Response struct {
+			Backups []serverBackup `json:"backups"`
+		} `json:"response"`
+	}{}
+	if err := c.post(ctx, "server/listbackups", &data, struct {
+		ServerID string `json:"serverid"`
+	}{serverID}); err != nil {
+		return nil, err
+	}
+	return data.Response.Backups, nil
+}
+
+// cloneServerParams are the arguments to server/clone. The new server gets
+// the disk of ServerID, or of the backup BackupID of ServerID when set.
+type cloneServerParams struct {
+	ServerID    string                        `json:"serverid"`
+	BackupID    string                        `json:"backupid,omitempty"`
+	Backup      []glesys.ServerBackupSchedule `json:"backupschedules,omitempty"`
+	Bandwidth   int                           `json:"bandwidth"`
+	CPU         int                           `json:"cpucores"`
+	DataCenter  string                        `json:"datacenter"`
+	Description string                        `json:"description,omitempty"`
+	Hostname    string                        `json:"hostname"`
+	IPv4        string                        `json:"ip"`
+	IPv6        string                        `json:"ipv6"`
+	Memory      int                           `json:"memorysize"`
+	Storage     int                           `json:"disksize"`
+}
+
+// cloneServer creates a server as a copy of an existing server or one of its
+// backups.
+func (c *apiClient) cloneServer(ctx context.Context, params cloneServerParams) (*glesys.ServerDetails, error) {
+	data := struct {
+		Response struct {
+			Server glesys.ServerDetails `json:"server"`
+		} `json:"response"`
+	}{}
+	if err := c.post(ctx, "server/clone", &data, params); err != nil {
+		return nil, err
+	}
+	return &data.Response.Server, nil
+}
+
+// serverUsage is the usage of one resource of a server, as reported by
+// server/status.
+type serverUsage struct {
+	Usage float64 `json:"usage"`
+	Max   float64 `json:"max"`
+	Unit  string  `json:"unit"`
+}
+
+// serverStatus is the state and resource usage of a server.
+type serverStatus struct {
+	State    string      `json:"state"`
+	CPU      serverUsage `json:"cpu"`
+	Memory   serverUsage `json:"memory"`
+	Disk     serverUsage `json:"disk"`
+	Transfer serverUsage `json:"transfer"`
+	Uptime   struct {
+		Current int    `json:"current"`
+		Unit    string `json:"unit"`
+	} `json:"uptime"`
+}
+
+// serverStatus returns the state and resource usage of a server.
+func (c *apiClient) serverStatus(ctx context.Context, serverID string) (*serverStatus, error) {
+	data := struct {
+		Response struct {
+			Server serverStatus `json:"server"`
+		} `json:"response"`
+	}{}
+	if err := c.post(ctx, "server/status", &data, struct {
+		ServerID string `json:"serverid"`
+	}{serverID}); err != nil {
+		return nil, err
+	}
+	return &data.Response.Server, nil
+}
+
+// serverLimit is a limit of a server, as listed by server/limits. Failcount
+// is the number of times the limit has been exceeded since it was reset.
+type serverLimit struct {
+	Current   float64 `json:"current"`
+	Limit     float64 `json:"limit"`
+	Failcount int     `json:"failcount"`
+}
+
+// serverLimits returns the limits of a server, keyed by limit name.
+func (c *apiClient) serverLimits(ctx context.Context, serverID string) (map[string]serverLimit, error) {
+	data := struct {
+		Response struct {
+			Limits map[string]serverLimit `json:"limits"`
+		} `json:"response"`
+	}{}
+	if err := c.post(ctx, "server/limits", &data, struct {
+		ServerID string `json:"serverid"`
+	}{serverID}); err != nil {
+		return nil, err
+	}
+	return data.Response.Limits, nil
+}
+
+// resetServerLimit resets the failcount of a limit of a server.
+func (c *apiClient) resetServerLimit(ctx context.Context, serverID string, limit string) error {
+	return c.post(ctx, "server/resetlimit", nil, struct {
+		ServerID string `json:"serverid"`
+		Type     string `json:"type"`
+	}{serverID, limit})
+}
@@ -1,25 +1,52 @@
 package glesys
 
 import (
-	"github.com/glesys/glesys-go/v8"
+	"net/http"
+	"time"
 )
 
 // Config - Provider configuration
 type Config struct {
-	UserID      string
-	Token       string
-	UserAgent   string
-	APIEndpoint string
+	UserID       string
+	Token        string
+	UserAgent    string
+	APIEndpoint  string
+	MaxRetries   int
+	RetryMaxWait time.Duration
+
+	// MaxConcurrentRequests caps the number of API requests in flight, zero
+	// means no limit.
+	MaxConcurrentRequests int
+
+	// CostWarningThreshold is the estimated monthly cost of a single
+	// resource above which a warning is shown, zero means no warning.
+	CostWarningThreshold float64
 }
 
-// Client - Setup new glesys client
-func (c *Config) Client() (*glesys.Client, error) {
-	client := glesys.NewClient(c.UserID, c.Token, "tf-glesys/0.17.0")
+// Client - Setup new glesys client. Only call this while configuring the
+// provider, see newGlesysClient.
+func (c *Config) Client() (*apiClient, error) {
+	userAgent := "tf-glesys/0.17.0"
+	transport := http.DefaultTransport
+	if c.MaxConcurrentRequests > 0 {
+		transport = newLimitTransport(transport, c.MaxConcurrentRequests)
+	}
+	httpClient := newRetryClient(transport, c.MaxRetries, c.RetryMaxWait)
+
+	client := newGlesysClient(c.UserID, c.Token, userAgent, httpClient)
 
 	err := client.SetBaseURL(c.APIEndpoint)
 	if err != nil {
 		return nil, err
 	}
 
-	return client, nil
+	return &apiClient{
+		Client:     client,
+		userID:     c.UserID,
+		token:      c.Token,
+		userAgent:  userAgent,
+		httpClient: httpClient,
+
+		costWarningThreshold: c.CostWarningThreshold,
+	}, nil
 }
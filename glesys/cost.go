@@ -0,0 +1,161 @@
+package glesys
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/glesys/glesys-go/v8"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// estimatedCostSchema is the computed estimated_cost block, set at plan time
+// from the estimatedcost endpoint of the API.
+func estimatedCostSchema() *schema.Schema {
+	return &schema.Schema{
+		Description: "Estimated cost of the resource with the planned settings. Only estimated when the resource is created or resized.",
+		Type:        schema.TypeList,
+		Computed:    true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"amount": {
+					Description: "Estimated amount, including discounts.",
+					Type:        schema.TypeFloat,
+					Computed:    true,
+				},
+				"currency": {
+					Description: "Currency of the amount.",
+					Type:        schema.TypeString,
+					Computed:    true,
+				},
+				"time_period": {
+					Description: "Time period the amount covers.",
+					Type:        schema.TypeString,
+					Computed:    true,
+				},
+			},
+		},
+	}
+}
+
+// serverEstimatedCostParams is used to estimate the cost of a new server, or
+// of changes to an existing one when ServerID is set.
+type serverEstimatedCostParams struct {
+	ServerID   string `json:"serverid,omitempty"`
+	Bandwidth  int    `json:"bandwidth,omitempty"`
+	CPU        int    `json:"cpucores,omitempty"`
+	DataCenter string `json:"datacenter,omitempty"`
+	Memory     int    `json:"memorysize,omitempty"`
+	Platform   string `json:"platform,omitempty"`
+	Storage    int    `json:"disksize,omitempty"`
+	Template   string `json:"templatename,omitempty"`
+}
+
+// serverDiskEstimatedCostParams is used to estimate the cost of an additional
+// disk.
+type serverDiskEstimatedCostParams struct {
+	ServerID  string `json:"serverid"`
+	SizeInGIB int    `json:"sizeingib"`
+	Type      string `json:"type,omitempty"`
+}
+
+func (c *apiClient) serverEstimatedCost(ctx context.Context, params serverEstimatedCostParams) (*glesys.Billing, error) {
+	data := struct {
+		Response struct {
+			Billing glesys.Billing `json:"billing"`
+		} `json:"response"`
+	}{}
+	err := c.post(ctx, "server/estimatedcost", &data, params)
+	return &data.Response.Billing, err
+}
+
+func (c *apiClient) serverDiskEstimatedCost(ctx context.Context, params serverDiskEstimatedCostParams) (*glesys.Billing, error) {
+	data := struct {
+		Response struct {
+			Billing glesys.Billing `json:"billing"`
+		} `json:"response"`
+	}{}
+	err := c.post(ctx, "serverdisk/estimatedcost", &data, params)
+	return &data.Response.Billing, err
+}
+
+// setEstimatedCost plans estimated_cost from billing. The API estimates the
+// cost per month.
+func setEstimatedCost(d *schema.ResourceDiff, client *apiClient, resource string, billing *glesys.Billing) error {
+	if threshold := client.costWarningThreshold; threshold > 0 && billing.Estimated.Total > threshold {
+		log.Printf("[WARN] planned %s (%s) is estimated to cost %.2f %s per month, above cost_warning_threshold %.2f",
+			resource, d.Id(), billing.Estimated.Total, billing.Currency, threshold)
+	}
+
+	return d.SetNew("estimated_cost", []map[string]interface{}{
+		{
+			"amount":      billing.Estimated.Total,
+			"currency":    billing.Currency,
+			"time_period": "month",
+		},
+	})
+}
+
+// needsCostEstimate reports whether estimated_cost should be planned again,
+// because the resource is new or one of keys changed. Resources without an
+// estimate, such as those created by older versions of the provider, don't get
+// one until they are resized. Nothing is estimated while any of keys is
+// unknown.
+func needsCostEstimate(d *schema.ResourceDiff, keys ...string) bool {
+	for _, key := range keys {
+		if !d.NewValueKnown(key) {
+			return false
+		}
+	}
+
+	if d.Id() == "" {
+		return true
+	}
+	for _, key := range keys {
+		if d.HasChange(key) {
+			return true
+		}
+	}
+	return false
+}
+
+// keepCostEstimate keeps estimated_cost of an existing resource as it is. The
+// SDK plans missing computed values as unknown, which would plan an update of
+// resources without an estimate.
+func keepCostEstimate(d *schema.ResourceDiff) error {
+	if d.Id() == "" {
+		return nil
+	}
+	return d.Clear("estimated_cost")
+}
+
+// costWarning returns a warning when the estimated cost of the resource is
+// above the cost_warning_threshold of the provider. The SDK can't add warnings
+// to a plan, so the warning is shown when the change is applied.
+func costWarning(d *schema.ResourceData, m interface{}, resource string) diag.Diagnostics {
+	client := m.(*apiClient)
+	if client.costWarningThreshold <= 0 {
+		return nil
+	}
+
+	costs := d.Get("estimated_cost").([]interface{})
+	if len(costs) == 0 || costs[0] == nil {
+		return nil
+	}
+	cost := costs[0].(map[string]interface{})
+
+	amount := cost["amount"].(float64)
+	if amount <= client.costWarningThreshold {
+		return nil
+	}
+
+	return diag.Diagnostics{
+		{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("Estimated cost of %s is above cost_warning_threshold", resource),
+			Detail: fmt.Sprintf("%s (%s) is estimated to cost %.2f %s per %s, above the threshold of %.2f.",
+				resource, d.Id(), amount, cost["currency"], cost["time_period"], client.costWarningThreshold),
+		},
+	}
+}
@@ -0,0 +1,201 @@
+package glesys
+
+import (
+	"context"
+	"testing"
+
+	"github.com/glesys/glesys-go/v8"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestEstimatedCostPlanned(t *testing.T) {
+	_, client := newFakeClient(t)
+
+	srv, err := client.Servers.Create(context.Background(), glesys.CreateServerParams{
+		Bandwidth:  100,
+		CPU:        2,
+		DataCenter: "Falkenberg",
+		Hostname:   "tf-test",
+		Memory:     2048,
+		Platform:   "KVM",
+		Storage:    20,
+		Template:   "Debian 12 (Bookworm)",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server := map[string]interface{}{
+		"hostname":   "tf-test",
+		"platform":   "KVM",
+		"datacenter": "Falkenberg",
+		"bandwidth":  100,
+		"cpu":        2,
+		"memory":     2048,
+		"storage":    20,
+		"template":   "Debian 12 (Bookworm)",
+	}
+
+	for _, tt := range []struct {
+		name   string
+		state  *terraform.InstanceState
+		config map[string]interface{}
+		want   string
+	}{
+		{
+			name:   "new_server",
+			config: server,
+			want:   "340",
+		},
+		{
+			name: "server_resized",
+			state: &terraform.InstanceState{ID: srv.ID, Attributes: map[string]string{
+				"id":                           srv.ID,
+				"hostname":                     "tf-test",
+				"platform":                     "KVM",
+				"datacenter":                   "Falkenberg",
+				"bandwidth":                    "100",
+				"cpu":                          "2",
+				"memory":                       "2048",
+				"storage":                      "20",
+				"template":                     "Debian 12 (Bookworm)",
+				"estimated_cost.#":             "1",
+				"estimated_cost.0.amount":      "340",
+				"estimated_cost.0.currency":    "SEK",
+				"estimated_cost.0.time_period": "month",
+			}},
+			config: map[string]interface{}{
+				"hostname":   "tf-test",
+				"platform":   "KVM",
+				"datacenter": "Falkenberg",
+				"bandwidth":  100,
+				"cpu":        4,
+				"memory":     2048,
+				"storage":    20,
+				"template":   "Debian 12 (Bookworm)",
+			},
+			want: "540",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			diff, err := resourceGlesysServer().Diff(context.Background(), tt.state, terraform.NewResourceConfigRaw(tt.config), client)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got := diff.Attributes["estimated_cost.0.amount"].New; got != tt.want {
+				t.Errorf("got estimated amount %q, want %q", got, tt.want)
+			}
+			if got := diff.Attributes["estimated_cost.0.time_period"]; got != nil && got.New != "month" {
+				t.Errorf("got time period %q, want %q", got.New, "month")
+			}
+		})
+	}
+
+	t.Run("server_without_estimate", func(t *testing.T) {
+		// Servers created by older versions of the provider have no
+		// estimate, and shouldn't plan an update because of it.
+		state := &terraform.InstanceState{ID: srv.ID, Attributes: map[string]string{
+			"id":         srv.ID,
+			"hostname":   "tf-test",
+			"platform":   "KVM",
+			"datacenter": "Falkenberg",
+			"bandwidth":  "100",
+			"cpu":        "2",
+			"memory":     "2048",
+			"storage":    "20",
+			"template":   "Debian 12 (Bookworm)",
+		}}
+		diff, err := resourceGlesysServer().Diff(context.Background(), state, terraform.NewResourceConfigRaw(server), client)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if got := diff.Attributes["estimated_cost.0.amount"]; got != nil {
+			t.Errorf("got estimated amount %q, want none", got.New)
+		}
+		if got := diff.Attributes["estimated_cost.#"]; got != nil {
+			t.Errorf("got estimated_cost planned %+v, want no change", got)
+		}
+	})
+
+	t.Run("new_disk", func(t *testing.T) {
+		config := terraform.NewResourceConfigRaw(map[string]interface{}{
+			"serverid": srv.ID,
+			"size":     20,
+			"type":     "gold",
+		})
+		diff, err := resourceGlesysServerDisk().Diff(context.Background(), nil, config, client)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if got := diff.Attributes["estimated_cost.0.amount"].New; got != "80" {
+			t.Errorf("got estimated amount %q, want %q", got, "80")
+		}
+	})
+
+	t.Run("new_database", func(t *testing.T) {
+		config := terraform.NewResourceConfigRaw(map[string]interface{}{
+			"name":          "tf-test",
+			"engine":        "mysql",
+			"engineversion": "8.0",
+			"datacenterkey": "dc-fbg1",
+			"plankey":       "plan-1core-4gib-25gib",
+		})
+		diff, err := resourceGlesysDatabase().Diff(context.Background(), nil, config, client)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if got := diff.Attributes["estimated_cost.0.currency"].New; got != "SEK" {
+			t.Errorf("got currency %q, want %q", got, "SEK")
+		}
+	})
+
+	t.Run("unknown_server", func(t *testing.T) {
+		config := terraform.NewResourceConfigRaw(map[string]interface{}{
+			"serverid": "74245c2b-7d2e-4a75-b4b3-a5e4c8d6c2b1",
+			"size":     20,
+		})
+		diff, err := resourceGlesysServerDisk().Diff(context.Background(), nil, config, client)
+		if err != nil {
+			t.Fatalf("failed estimates should not fail the plan: %s", err)
+		}
+		if got := diff.Attributes["estimated_cost.0.amount"]; got != nil {
+			t.Errorf("got estimated amount %q, want none", got.New)
+		}
+	})
+}
+
+func TestCostWarning(t *testing.T) {
+	_, client := newFakeClient(t)
+
+	d := resourceGlesysServerDisk().TestResourceData()
+	d.SetId("disk-1")
+	d.Set("estimated_cost", []map[string]interface{}{
+		{"amount": 80.0, "currency": "SEK", "time_period": "month"},
+	})
+
+	for _, tt := range []struct {
+		threshold float64
+		want      diag.Diagnostics
+	}{
+		{threshold: 0},
+		{threshold: 100},
+		{threshold: 50, want: diag.Diagnostics{{
+			Severity: diag.Warning,
+			Summary:  "Estimated cost of server disk is above cost_warning_threshold",
+			Detail:   "server disk (disk-1) is estimated to cost 80.00 SEK per month, above the threshold of 50.00.",
+		}}},
+	} {
+		client.costWarningThreshold = tt.threshold
+
+		got := costWarning(d, client, "server disk")
+		if len(got) != len(tt.want) {
+			t.Fatalf("threshold %v: got %v, want %v", tt.threshold, got, tt.want)
+		}
+		for i := range got {
+			if got[i].Summary != tt.want[i].Summary || got[i].Detail != tt.want[i].Detail || got[i].Severity != tt.want[i].Severity {
+				t.Errorf("threshold %v: got %+v, want %+v", tt.threshold, got[i], tt.want[i])
+			}
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package glesys
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/glesys/glesys-go/v8"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"gopkg.in/yaml.v3"
+)
+
+func dataSourceGlesysCloudConfig() *schema.Resource {
+	return &schema.Resource{
+		Description: "Render a cloud-config mustache template the way `glesys_server` does, to check the result before creating a server.",
+
+		ReadContext: dataSourceGlesysCloudConfigRead,
+		Schema: map[string]*schema.Schema{
+			"cloudconfig": {
+				Description: "Cloud-config mustache template, as in `cloudconfig` of `glesys_server`.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"cloudconfigparams": {
+				Description: "Parameters for the template, as in `cloudconfigparams` of `glesys_server`.",
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"rendered": {
+				Description: "The rendered cloud-config.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func dataSourceGlesysCloudConfigRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	client := m.(*apiClient)
+
+	template := d.Get("cloudconfig").(string)
+	params := d.Get("cloudconfigparams").(map[string]any)
+
+	if err := checkCloudConfigParams(template, params); err != nil {
+		return diag.FromErr(err)
+	}
+
+	preview, err := client.Servers.PreviewCloudConfig(ctx, glesys.PreviewCloudConfigParams{
+		CloudConfig:       template,
+		CloudConfigParams: params,
+	})
+	if err != nil {
+		return diag.Errorf("Error rendering cloudconfig: %s", err)
+	}
+	if err := checkRenderedCloudConfig(preview.Preview); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.Set("rendered", preview.Preview)
+	d.SetId(strconv.Itoa(schema.HashString(preview.Preview)))
+
+	return nil
+}
+
+// cloudConfigParamTag matches variable tags referring to a parameter, e.g.
+// {{ params.name }} or {{{params.name}}}. Sections like {{#params.name}} are
+// left out, as they are allowed to refer to parameters that aren't set.
+var cloudConfigParamTag = regexp.MustCompile(`\{\{\{?\s*&?\s*params\.([A-Za-z0-9_-]+)[^}]*\}\}`)
+
+// checkCloudConfigParams returns an error listing the parameters used by
+// template that are missing from params. Mustache renders them as empty
+// strings, which is rarely what was intended.
+func checkCloudConfigParams(template string, params map[string]any) error {
+	var missing []string
+	for _, match := range cloudConfigParamTag.FindAllStringSubmatch(template, -1) {
+		name := match[1]
+		if _, ok := params[name]; ok {
+			continue
+		}
+		if !slices.Contains(missing, name) {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+
+	sort.Strings(missing)
+	return fmt.Errorf("cloudconfig: params used by the template are missing from cloudconfigparams: %s", strings.Join(missing, ", "))
+}
+
+// checkRenderedCloudConfig checks that rendered starts with the #cloud-config
+// header and is valid YAML.
+func checkRenderedCloudConfig(rendered string) error {
+	lines := strings.Split(rendered, "\n")
+
+	var errs []error
+	if strings.TrimRight(lines[0], " \r") != "#cloud-config" {
+		errs = append(errs, fmt.Errorf("cloudconfig: the rendered template must start with a \"#cloud-config\" line, got %q", lines[0]))
+	}
+	var doc interface{}
+	if err := yaml.Unmarshal([]byte(rendered), &doc); err != nil {
+		errs = append(errs, fmt.Errorf("cloudconfig: the rendered template isn't valid YAML: %s", strings.TrimPrefix(err.Error(), "yaml: ")))
+	}
+	return errors.Join(errs...)
+}
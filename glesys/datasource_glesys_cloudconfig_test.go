@@ -0,0 +1,111 @@
+package glesys
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+const testCloudConfig = `#cloud-config
+hostname: {{ params.hostname }}
+packages:
+{{#params.extra_package}}
+  - {{params.extra_package}}
+{{/params.extra_package}}
+  - nginx
+`
+
+func TestDataSourceGlesysCloudConfigRead(t *testing.T) {
+	_, client := newFakeClient(t)
+
+	for _, tt := range []struct {
+		name         string
+		config       map[string]interface{}
+		wantRendered string
+		wantErr      string
+	}{
+		{
+			name: "rendered",
+			config: map[string]interface{}{
+				"cloudconfig":       testCloudConfig,
+				"cloudconfigparams": map[string]interface{}{"hostname": "www1", "extra_package": "htop"},
+			},
+			wantRendered: "#cloud-config\nhostname: www1\n",
+		},
+		{
+			name: "missing_params",
+			config: map[string]interface{}{
+				"cloudconfig": testCloudConfig,
+			},
+			wantErr: "params used by the template are missing from cloudconfigparams: extra_package, hostname",
+		},
+		{
+			name: "missing_header",
+			config: map[string]interface{}{
+				"cloudconfig": "hostname: www1\n",
+			},
+			wantErr: "must start with a \"#cloud-config\" line",
+		},
+		{
+			name: "tab_indentation",
+			config: map[string]interface{}{
+				"cloudconfig": "#cloud-config\npackages:\n\t- nginx\n",
+			},
+			wantErr: "the rendered template isn't valid YAML: line 3: found character that cannot start any token",
+		},
+		{
+			name: "invalid_yaml",
+			config: map[string]interface{}{
+				"cloudconfig": "#cloud-config\npackages: [nginx, htop\n",
+			},
+			wantErr: "the rendered template isn't valid YAML",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, dataSourceGlesysCloudConfig().Schema, tt.config)
+
+			diags := dataSourceGlesysCloudConfigRead(context.Background(), d, client)
+			if tt.wantErr != "" {
+				if !diags.HasError() || !strings.Contains(diags[0].Summary, tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, diags)
+				}
+				return
+			}
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+			if got := d.Get("rendered").(string); !strings.HasPrefix(got, tt.wantRendered) {
+				t.Errorf("got rendered %q, want prefix %q", got, tt.wantRendered)
+			}
+		})
+	}
+}
+
+func TestResourceGlesysServerValidateCloudConfig(t *testing.T) {
+	_, client := newFakeClient(t)
+
+	config := map[string]interface{}{
+		"hostname":    "tf-test",
+		"platform":    "KVM",
+		"datacenter":  "Falkenberg",
+		"bandwidth":   100,
+		"cpu":         2,
+		"memory":      2048,
+		"storage":     20,
+		"template":    "debian-12",
+		"cloudconfig": testCloudConfig,
+	}
+
+	_, err := resourceGlesysServer().Diff(context.Background(), nil, terraform.NewResourceConfigRaw(config), client)
+	if err == nil || !strings.Contains(err.Error(), "missing from cloudconfigparams: extra_package, hostname") {
+		t.Fatalf("expected missing params error, got %v", err)
+	}
+
+	config["cloudconfigparams"] = map[string]interface{}{"hostname": "www1", "extra_package": ""}
+	if _, err := resourceGlesysServer().Diff(context.Background(), nil, terraform.NewResourceConfigRaw(config), client); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+}
@@ -3,7 +3,6 @@ package glesys
 import (
 	"context"
 
-	"github.com/glesys/glesys-go/v8"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -57,16 +56,14 @@ func dataSourceGlesysDNSDomain() *schema.Resource {
 }
 
 func dataSourceGlesysDomainRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*glesys.Client)
+	client := m.(*apiClient)
 
 	name := d.Get("name").(string)
 
 	domain, err := client.DNSDomains.Details(ctx, name)
 
 	if err != nil {
-		diag.Errorf("domain not found: %v", err)
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error retrieving domain: %s", err)
 	}
 
 	d.SetId(domain.Name)
@@ -54,14 +54,12 @@ func dataSourceGlesysIP() *schema.Resource {
 }
 
 func dataSourceGlesysIPRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
-	client := m.(*glesys.Client)
+	client := m.(*apiClient)
 
 	var ip *glesys.IP
 	ip, err := client.IPs.Details(ctx, d.Get("address").(string))
 	if err != nil {
-		diag.Errorf("IP not found: %s", err)
-		d.SetId("")
-		return nil
+		return diag.Errorf("Error retrieving IP: %s", err)
 	}
 
 	d.Set("address", ip.Address)
@@ -39,7 +39,7 @@ func dataSourceGlesysNetwork() *schema.Resource {
 }
 
 func dataSourceGlesysNetworkRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*glesys.Client)
+	client := m.(*apiClient)
 
 	var network *glesys.Network
 	if networkid, ok := d.GetOk("id"); ok {
@@ -44,7 +44,7 @@ func dataSourceGlesysNetworkAdapter() *schema.Resource {
 }
 
 func dataSourceGlesysNetworkAdapterRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*glesys.Client)
+	client := m.(*apiClient)
 
 	var na *glesys.NetworkAdapter
 	if adapterID, ok := d.GetOk("id"); ok {
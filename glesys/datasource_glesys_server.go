@@ -0,0 +1,307 @@
+package glesys
+
+import (
+	"context"
+	"strings"
+
+	"github.com/glesys/glesys-go/v8"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceGlesysServer() *schema.Resource {
+	return &schema.Resource{
+		Description: "Get information about a server in your Glesys Project, by ID or hostname.",
+
+		ReadContext: dataSourceGlesysServerRead,
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Description:  "Server ID.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"id", "hostname"},
+			},
+			"hostname": {
+				Description:  "Server hostname, must match exactly one server in the project.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"id", "hostname"},
+			},
+			"backups_schedule": {
+				Description: "KVM Server backup schedule definition.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"frequency": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"retention": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"bandwidth": {
+				Description: "Server network adapter bandwidth",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"cpu": {
+				Description: "Server CPU cores count",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"datacenter": {
+				Description: "Server datacenter placement",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"description": {
+				Description: "Server description",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"extra_disks": {
+				Description: "Additional disks associated with the server.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"ip_list": {
+				Description: "IP addresses of the server.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"address": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"version": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"ipv4_address": {
+				Description: "Primary IPv4 address of the server.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"ipv6_address": {
+				Description: "Primary IPv6 address of the server.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"islocked": {
+				Description: "Server locked state",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+			"isrunning": {
+				Description: "Server running state",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+			"memory": {
+				Description: "Server RAM setting",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"network_adapters": {
+				Description: "Network adapters associated with the server.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"adaptertype": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"bandwidth": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"networkid": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"primary_networkadapter_network": {
+				Description: "Network of the primary network adapter.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"platform": {
+				Description: "Server virtualisation platform, `KVM` or `VMware`",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"power_state": {
+				Description: "Server power state, `running` or `stopped`.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"storage": {
+				Description: "Server disk space",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"template": {
+				Description: "Server OS template",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"template_id": {
+				Description: "ID of the template the server was installed from.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"template_name": {
+				Description: "Name of the template the server was installed from.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func dataSourceGlesysServerRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	client := m.(*apiClient)
+
+	id := d.Get("id").(string)
+	if hostname, ok := d.GetOk("hostname"); ok {
+		servers, err := client.Servers.List(ctx)
+		if err != nil {
+			return diag.Errorf("Error listing servers: %s", err)
+		}
+
+		var ids []string
+		for _, s := range *servers {
+			if s.Hostname == hostname.(string) {
+				ids = append(ids, s.ID)
+			}
+		}
+		switch len(ids) {
+		case 0:
+			return diag.Errorf("no server with hostname %q found", hostname)
+		case 1:
+			id = ids[0]
+		default:
+			return diag.Errorf("%d servers with hostname %q found, use id instead: %s", len(ids), hostname, strings.Join(ids, ", "))
+		}
+	}
+
+	srv, err := client.Servers.Details(ctx, id)
+	if err != nil {
+		return diag.Errorf("Error retrieving server (%s): %s", id, err)
+	}
+
+	adapters, err := client.Servers.NetworkAdapters(ctx, srv.ID)
+	if err != nil {
+		return diag.Errorf("Error retrieving network adapters of server (%s): %s", srv.ID, err)
+	}
+
+	d.SetId(srv.ID)
+	d.Set("bandwidth", srv.Bandwidth)
+	d.Set("cpu", srv.CPU)
+	d.Set("datacenter", srv.DataCenter)
+	d.Set("description", srv.Description)
+	d.Set("hostname", srv.Hostname)
+	d.Set("islocked", srv.IsLocked)
+	d.Set("isrunning", srv.IsRunning)
+	d.Set("memory", srv.Memory)
+	d.Set("platform", srv.Platform)
+	d.Set("storage", srv.Storage)
+	d.Set("template", srv.Template)
+	d.Set("template_id", srv.InitialTemplate.ID)
+	d.Set("template_name", srv.InitialTemplate.Name)
+	if srv.IsRunning {
+		d.Set("power_state", "running")
+	} else {
+		d.Set("power_state", "stopped")
+	}
+
+	ipv4, ipv6 := serverAddresses(srv)
+	d.Set("ipv4_address", ipv4)
+	d.Set("ipv6_address", ipv6)
+
+	var ips []map[string]interface{}
+	for _, ip := range srv.IPList {
+		ips = append(ips, map[string]interface{}{
+			"address": ip.Address,
+			"version": ip.Version,
+		})
+	}
+	if err := d.Set("ip_list", ips); err != nil {
+		return diag.Errorf("unable to set ip_list, read value %v", err)
+	}
+
+	var diskIDs []string
+	for _, disk := range srv.AdditionalDisks {
+		diskIDs = append(diskIDs, disk.ID)
+	}
+	d.Set("extra_disks", diskIDs)
+
+	var backupSchedules []map[string]interface{}
+	for _, bs := range srv.Backup.Schedules {
+		backupSchedules = append(backupSchedules, map[string]interface{}{
+			"frequency": bs.Frequency,
+			"retention": bs.Numberofimagestokeep,
+		})
+	}
+	if err := d.Set("backups_schedule", backupSchedules); err != nil {
+		return diag.Errorf("unable to set backups_schedule, read value %v", err)
+	}
+
+	var nics []map[string]interface{}
+	for _, v := range *adapters {
+		if v.Name == "Network adapter 1" || v.IsPrimary {
+			d.Set("primary_networkadapter_network", v.NetworkID)
+		}
+		nics = append(nics, map[string]interface{}{
+			"id":          v.ID,
+			"adaptertype": v.AdapterType,
+			"bandwidth":   v.Bandwidth,
+			"name":        v.Name,
+			"networkid":   v.NetworkID,
+		})
+	}
+	if err := d.Set("network_adapters", nics); err != nil {
+		return diag.Errorf("unable to set network_adapters, read value %v", err)
+	}
+
+	return nil
+}
+
+// serverAddresses returns the primary IPv4 and IPv6 address of the server, or
+// "" if it has none. The API lists the primary address of each version first,
+// before any addresses added later.
+func serverAddresses(srv *glesys.ServerDetails) (string, string) {
+	var ipv4, ipv6 string
+	for _, ip := range srv.IPList {
+		switch {
+		case ip.Version == 4 && ipv4 == "":
+			ipv4 = ip.Address
+		case ip.Version == 6 && ipv6 == "":
+			ipv6 = ip.Address
+		}
+	}
+	return ipv4, ipv6
+}
@@ -0,0 +1,71 @@
+package glesys
+
+import (
+	"context"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceGlesysServerBackups() *schema.Resource {
+	return &schema.Resource{
+		Description: "List the backup images of a KVM server, taken according to its `backups_schedule`. Use `source_backup_id` of `glesys_server` to restore one to a new server.",
+
+		ReadContext: dataSourceGlesysServerBackupsRead,
+		Schema: map[string]*schema.Schema{
+			"serverid": {
+				Description: "KVM server ID.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"backups": {
+				Description: "Backup images of the server, the most recent first.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Description: "Backup ID, can be used as `source_backup_id` of a `glesys_server`.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"created_at": {
+							Description: "Time the backup was taken, in RFC 3339 format.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceGlesysServerBackupsRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	client := m.(*apiClient)
+
+	serverID := d.Get("serverid").(string)
+	backups, err := client.listServerBackups(ctx, serverID)
+	if err != nil {
+		return diag.Errorf("Error listing backups of server (%s): %s", serverID, err)
+	}
+	sort.SliceStable(backups, func(i, j int) bool {
+		return backups[i].CreatedAt > backups[j].CreatedAt
+	})
+
+	list := make([]map[string]interface{}, 0, len(backups))
+	for _, b := range backups {
+		list = append(list, map[string]interface{}{
+			"id":         b.ID,
+			"created_at": b.CreatedAt,
+		})
+	}
+
+	if err := d.Set("backups", list); err != nil {
+		return diag.Errorf("unable to set backups, read value %v", err)
+	}
+	d.SetId(serverID)
+
+	return nil
+}
@@ -0,0 +1,72 @@
+package glesys
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceGlesysServerConsole() *schema.Resource {
+	return &schema.Resource{
+		Description: "Get the console connection details of a server. The details, including the password, are stored in the Terraform state.",
+
+		ReadContext: dataSourceGlesysServerConsoleRead,
+		Schema: map[string]*schema.Schema{
+			"serverid": {
+				Description: "Server ID.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"host": {
+				Description: "Console host.",
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"port": {
+				Description: "Console port.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"password": {
+				Description: "Console password.",
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"protocol": {
+				Description: "Console protocol, e.g. `vnc`.",
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"url": {
+				Description: "URL of the web console.",
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+			},
+		},
+	}
+}
+
+func dataSourceGlesysServerConsoleRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	client := m.(*apiClient)
+
+	serverID := d.Get("serverid").(string)
+	console, err := client.Servers.Console(ctx, serverID)
+	if err != nil {
+		return diag.Errorf("Error retrieving console of server (%s): %s", serverID, err)
+	}
+
+	d.Set("host", console.Host)
+	d.Set("port", console.Port)
+	d.Set("password", console.Password)
+	d.Set("protocol", console.Protocol)
+	d.Set("url", console.URL)
+	d.SetId(serverID)
+
+	return nil
+}
@@ -0,0 +1,44 @@
+package glesys
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceGlesysServerISOs() *schema.Resource {
+	return &schema.Resource{
+		Description: "List the ISO files available to a VMware server, for use with `glesys_server_iso`.",
+
+		ReadContext: dataSourceGlesysServerISOsRead,
+		Schema: map[string]*schema.Schema{
+			"serverid": {
+				Description: "VMware server ID.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"isofiles": {
+				Description: "ISO files available to the server.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func dataSourceGlesysServerISOsRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	client := m.(*apiClient)
+
+	serverID := d.Get("serverid").(string)
+	isoFiles, err := client.Servers.ListISOs(ctx, serverID)
+	if err != nil {
+		return diag.Errorf("Error listing ISO files for server (%s): %s", serverID, err)
+	}
+
+	d.Set("isofiles", *isoFiles)
+	d.SetId(serverID)
+
+	return nil
+}
@@ -0,0 +1,161 @@
+package glesys
+
+import (
+	"context"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// serverUsageSchema is the schema of the resource usage attributes of the
+// glesys_server_status data source.
+func serverUsageSchema(description string) *schema.Schema {
+	return &schema.Schema{
+		Description: description,
+		Type:        schema.TypeList,
+		Computed:    true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"usage": {
+					Type:     schema.TypeFloat,
+					Computed: true,
+				},
+				"max": {
+					Type:     schema.TypeFloat,
+					Computed: true,
+				},
+				"unit": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+			},
+		},
+	}
+}
+
+func flattenServerUsage(u serverUsage) []map[string]interface{} {
+	return []map[string]interface{}{
+		{
+			"usage": u.Usage,
+			"max":   u.Max,
+			"unit":  u.Unit,
+		},
+	}
+}
+
+func dataSourceGlesysServerStatus() *schema.Resource {
+	return &schema.Resource{
+		Description: "Get the current resource usage and limits of a server. Use `glesys_server_limits` to reset exceeded limits.",
+
+		ReadContext: dataSourceGlesysServerStatusRead,
+		Schema: map[string]*schema.Schema{
+			"serverid": {
+				Description: "Server ID.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"state": {
+				Description: "Server state, e.g. `running`.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"cpu":      serverUsageSchema("CPU usage."),
+			"memory":   serverUsageSchema("Memory usage."),
+			"disk":     serverUsageSchema("Disk usage."),
+			"transfer": serverUsageSchema("Transfer used this month, `max` is the quota."),
+			"uptime": {
+				Description: "Time since the server was started.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"current": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"unit": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"limits": {
+				Description: "Limits of the server, sorted by name.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"current": {
+							Type:     schema.TypeFloat,
+							Computed: true,
+						},
+						"limit": {
+							Type:     schema.TypeFloat,
+							Computed: true,
+						},
+						"failcount": {
+							Description: "Number of times the limit has been exceeded since it was reset.",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceGlesysServerStatusRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	client := m.(*apiClient)
+
+	serverID := d.Get("serverid").(string)
+	status, err := client.serverStatus(ctx, serverID)
+	if err != nil {
+		return diag.Errorf("Error retrieving status of server (%s): %s", serverID, err)
+	}
+	limits, err := client.serverLimits(ctx, serverID)
+	if err != nil {
+		return diag.Errorf("Error retrieving limits of server (%s): %s", serverID, err)
+	}
+
+	d.Set("state", status.State)
+	d.Set("cpu", flattenServerUsage(status.CPU))
+	d.Set("memory", flattenServerUsage(status.Memory))
+	d.Set("disk", flattenServerUsage(status.Disk))
+	d.Set("transfer", flattenServerUsage(status.Transfer))
+	d.Set("uptime", []map[string]interface{}{
+		{
+			"current": status.Uptime.Current,
+			"unit":    status.Uptime.Unit,
+		},
+	})
+
+	var names []string
+	for name := range limits {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	list := make([]map[string]interface{}, 0, len(names))
+	for _, name := range names {
+		l := limits[name]
+		list = append(list, map[string]interface{}{
+			"name":      name,
+			"current":   l.Current,
+			"limit":     l.Limit,
+			"failcount": l.Failcount,
+		})
+	}
+	if err := d.Set("limits", list); err != nil {
+		return diag.Errorf("unable to set limits, read value %v", err)
+	}
+	d.SetId(serverID)
+
+	return nil
+}
@@ -0,0 +1,247 @@
+package glesys
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/glesys/glesys-go/v8"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// createFakeServers creates a server for each hostname, on the platform and in
+// the datacenter given after the hostname, e.g. "bastion1:KVM:Falkenberg".
+func createFakeServers(t *testing.T, client *apiClient, servers ...string) map[string]*glesys.ServerDetails {
+	t.Helper()
+
+	created := map[string]*glesys.ServerDetails{}
+	for _, s := range servers {
+		parts := strings.Split(s, ":")
+		template := "debian-12"
+		if parts[1] == "VMware" {
+			template = "Debian 12 64-bit"
+		}
+		srv, err := client.Servers.Create(context.Background(), glesys.CreateServerParams{
+			Bandwidth:  100,
+			CPU:        2,
+			DataCenter: parts[2],
+			Hostname:   parts[0],
+			IPv4:       "any",
+			IPv6:       "any",
+			Memory:     2048,
+			Platform:   parts[1],
+			Storage:    20,
+			Template:   template,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		created[parts[0]] = srv
+	}
+	return created
+}
+
+func TestDataSourceGlesysServerRead(t *testing.T) {
+	_, client := newFakeClient(t)
+	servers := createFakeServers(t, client, "bastion1:KVM:Falkenberg", "web:KVM:Stockholm", "web:VMware:Stockholm")
+
+	for _, tt := range []struct {
+		name         string
+		config       map[string]interface{}
+		wantHostname string
+		wantErr      string
+	}{
+		{
+			name:         "id",
+			config:       map[string]interface{}{"id": servers["web"].ID},
+			wantHostname: "web",
+		},
+		{
+			name:         "hostname",
+			config:       map[string]interface{}{"hostname": "bastion1"},
+			wantHostname: "bastion1",
+		},
+		{
+			name:    "hostname_not_found",
+			config:  map[string]interface{}{"hostname": "bastion"},
+			wantErr: "no server with hostname \"bastion\" found",
+		},
+		{
+			name:    "hostname_not_unique",
+			config:  map[string]interface{}{"hostname": "web"},
+			wantErr: "2 servers with hostname \"web\" found",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, dataSourceGlesysServer().Schema, tt.config)
+
+			diags := dataSourceGlesysServerRead(context.Background(), d, client)
+			if tt.wantErr != "" {
+				if !diags.HasError() || !strings.Contains(diags[0].Summary, tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, diags)
+				}
+				return
+			}
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+
+			if got := d.Get("hostname").(string); got != tt.wantHostname {
+				t.Errorf("got hostname %q, want %q", got, tt.wantHostname)
+			}
+			if d.Get("ipv4_address").(string) == "" || d.Get("ipv6_address").(string) == "" {
+				t.Errorf("expected both addresses to be set, got %q and %q", d.Get("ipv4_address"), d.Get("ipv6_address"))
+			}
+			if got := len(d.Get("ip_list").([]interface{})); got != 2 {
+				t.Errorf("got %d IPs, want 2", got)
+			}
+			if got := len(d.Get("network_adapters").([]interface{})); got != 1 {
+				t.Errorf("got %d network adapters, want 1", got)
+			}
+		})
+	}
+}
+
+func TestDataSourceGlesysServerReadDetails(t *testing.T) {
+	_, client := newFakeClient(t)
+	ctx := context.Background()
+
+	srv, err := client.Servers.Create(ctx, glesys.CreateServerParams{
+		Backup:     []glesys.ServerBackupSchedule{{Frequency: "daily", Numberofimagestokeep: 7}},
+		Bandwidth:  100,
+		CPU:        2,
+		DataCenter: "Falkenberg",
+		Hostname:   "web",
+		IPv4:       "any",
+		IPv6:       "any",
+		Memory:     2048,
+		Platform:   "KVM",
+		Storage:    20,
+		Template:   "debian-12",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	primary, _ := serverAddresses(srv)
+
+	// An address added later isn't the primary address of the server.
+	free, err := client.IPs.Available(ctx, glesys.AvailableIPsParams{DataCenter: "Falkenberg", Platform: "KVM", Version: 4})
+	if err != nil {
+		t.Fatal(err)
+	}
+	extra, err := client.IPs.Reserve(ctx, (*free)[0].Address)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := client.addServerIP(ctx, srv.ID, extra.Address); err != nil {
+		t.Fatal(err)
+	}
+
+	d := schema.TestResourceDataRaw(t, dataSourceGlesysServer().Schema, map[string]interface{}{"id": srv.ID})
+	if diags := dataSourceGlesysServerRead(ctx, d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if got := d.Get("ipv4_address").(string); got != primary {
+		t.Errorf("got ipv4_address %q, want the primary address %q", got, primary)
+	}
+	if got := len(d.Get("ip_list").([]interface{})); got != 3 {
+		t.Errorf("got %d IPs, want 3", got)
+	}
+	if got := d.Get("primary_networkadapter_network").(string); got != "internet-falkenberg" {
+		t.Errorf("got primary_networkadapter_network %q, want %q", got, "internet-falkenberg")
+	}
+	if got := d.Get("backups_schedule.0.frequency").(string); got != "daily" {
+		t.Errorf("got backup frequency %q, want %q", got, "daily")
+	}
+	if got := d.Get("backups_schedule.0.retention").(int); got != 7 {
+		t.Errorf("got backup retention %d, want 7", got)
+	}
+}
+
+func TestDataSourceGlesysServersRead(t *testing.T) {
+	_, client := newFakeClient(t)
+	createFakeServers(t, client, "web2:KVM:Falkenberg", "web1:KVM:Stockholm", "db1:KVM:Falkenberg", "web3:VMware:Falkenberg")
+
+	for _, tt := range []struct {
+		name   string
+		config map[string]interface{}
+		want   []string
+	}{
+		{
+			name: "all",
+			want: []string{"db1", "web1", "web2", "web3"},
+		},
+		{
+			name:   "datacenter",
+			config: map[string]interface{}{"datacenter": "falkenberg"},
+			want:   []string{"db1", "web2", "web3"},
+		},
+		{
+			name:   "platform",
+			config: map[string]interface{}{"platform": "VMware"},
+			want:   []string{"web3"},
+		},
+		{
+			name:   "hostname_regex",
+			config: map[string]interface{}{"hostname_regex": "^web", "platform": "KVM"},
+			want:   []string{"web1", "web2"},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, dataSourceGlesysServers().Schema, tt.config)
+
+			if diags := dataSourceGlesysServersRead(context.Background(), d, client); diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+
+			var got []string
+			for _, v := range d.Get("servers").([]interface{}) {
+				s := v.(map[string]interface{})
+				if s["ipv4_address"].(string) == "" {
+					t.Errorf("expected ipv4_address of %s to be set", s["hostname"])
+				}
+				got = append(got, s["hostname"].(string))
+			}
+			if strings.Join(got, "|") != strings.Join(tt.want, "|") {
+				t.Errorf("got servers %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDataSourceGlesysServerConsoleRead(t *testing.T) {
+	_, client := newFakeClient(t)
+	ctx := context.Background()
+
+	srv := createFakeServers(t, client, "broken:KVM:Falkenberg")["broken"]
+
+	r := dataSourceGlesysServerConsole()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"serverid": srv.ID,
+	})
+	if diags := dataSourceGlesysServerConsoleRead(ctx, d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if d.Id() != srv.ID {
+		t.Errorf("got ID %q, want %q", d.Id(), srv.ID)
+	}
+	if got := d.Get("password").(string); got != "console-"+srv.ID {
+		t.Errorf("got password %q", got)
+	}
+	if got := d.Get("port").(int); got != 5900 {
+		t.Errorf("got port %d, want 5900", got)
+	}
+	for _, attr := range []string{"host", "port", "password", "protocol"} {
+		if !r.Schema[attr].Sensitive {
+			t.Errorf("expected %s to be sensitive", attr)
+		}
+	}
+
+	d = schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"serverid": "kvm999",
+	})
+	if diags := dataSourceGlesysServerConsoleRead(ctx, d, client); !diags.HasError() {
+		t.Error("expected error for a missing server")
+	}
+}
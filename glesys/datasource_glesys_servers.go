@@ -0,0 +1,137 @@
+package glesys
+
+import (
+	"context"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/glesys/glesys-go/v8"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceGlesysServers() *schema.Resource {
+	return &schema.Resource{
+		Description: "List the servers in your Glesys Project, optionally filtered by datacenter, platform and hostname.",
+
+		ReadContext: dataSourceGlesysServersRead,
+		Schema: map[string]*schema.Schema{
+			"datacenter": {
+				Description: "Only list servers in this datacenter.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"platform": {
+				Description: "Only list servers on this platform, `KVM` or `VMware`.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"hostname_regex": {
+				Description:  "Only list servers with a hostname matching this regular expression.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsValidRegExp,
+			},
+			"servers": {
+				Description: "Servers matching the filters, sorted by hostname.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"hostname": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"datacenter": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"platform": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"ipv4_address": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"ipv6_address": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceGlesysServersRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	client := m.(*apiClient)
+
+	servers, err := client.Servers.List(ctx)
+	if err != nil {
+		return diag.Errorf("Error listing servers: %s", err)
+	}
+
+	var hostnameRegex *regexp.Regexp
+	if v, ok := d.GetOk("hostname_regex"); ok {
+		hostnameRegex = regexp.MustCompile(v.(string))
+	}
+	datacenter := d.Get("datacenter").(string)
+	platform := d.Get("platform").(string)
+
+	var matches []glesys.Server
+	for _, s := range *servers {
+		if datacenter != "" && !strings.EqualFold(s.DataCenter, datacenter) {
+			continue
+		}
+		if platform != "" && !strings.EqualFold(s.Platform, platform) {
+			continue
+		}
+		if hostnameRegex != nil && !hostnameRegex.MatchString(s.Hostname) {
+			continue
+		}
+		matches = append(matches, s)
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].Hostname != matches[j].Hostname {
+			return matches[i].Hostname < matches[j].Hostname
+		}
+		return matches[i].ID < matches[j].ID
+	})
+
+	var ids []string
+	list := make([]map[string]interface{}, 0, len(matches))
+	for _, s := range matches {
+		// The list doesn't include the addresses of the servers.
+		srv, err := client.Servers.Details(ctx, s.ID)
+		if err != nil {
+			return diag.Errorf("Error retrieving server (%s): %s", s.ID, err)
+		}
+		ipv4, ipv6 := serverAddresses(srv)
+
+		ids = append(ids, s.ID)
+		list = append(list, map[string]interface{}{
+			"id":           s.ID,
+			"hostname":     s.Hostname,
+			"datacenter":   s.DataCenter,
+			"platform":     s.Platform,
+			"ipv4_address": ipv4,
+			"ipv6_address": ipv6,
+		})
+	}
+
+	if err := d.Set("servers", list); err != nil {
+		return diag.Errorf("unable to set servers, read value %v", err)
+	}
+	d.SetId(strconv.Itoa(schema.HashString(strings.Join(ids, ","))))
+
+	return nil
+}
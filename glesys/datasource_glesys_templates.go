@@ -0,0 +1,236 @@
+package glesys
+
+import (
+	"context"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceGlesysTemplates() *schema.Resource {
+	return &schema.Resource{
+		Description: "Get the server templates available on a platform, for use as `template` of a `glesys_server`.",
+
+		ReadContext: dataSourceGlesysTemplatesRead,
+		Schema: map[string]*schema.Schema{
+			"platform": {
+				Description: "Only list templates for this platform, `KVM` or `VMware`. All platforms are listed if unset.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"tag": {
+				Description: "Only list templates currently tagged with this tag, e.g. `debian-12`.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"name_regex": {
+				Description:  "Only list templates with a name matching this regular expression.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsValidRegExp,
+			},
+			"most_recent": {
+				Description: "Only list the matching template with the highest version in its name, e.g. `Ubuntu 24.04` over `Ubuntu 22.04`. Fails if no template matches.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"templates": {
+				Description: "Templates matching the filters.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Description: "Template ID, can be used as `template` of a `glesys_server`.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"name": {
+							Description: "Template name.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"platform": {
+							Description: "Template platform.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"os": {
+							Description: "Template operating system.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"current_tags": {
+							Description: "Tags currently pointing at the template.",
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"min_disk": {
+							Description: "Minimum server disk size in GIB.",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						"min_memory": {
+							Description: "Minimum server memory in MIB.",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						"instance_cost": {
+							Description: "Cost of the template, added to the cost of the server.",
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"amount": {
+										Type:     schema.TypeFloat,
+										Computed: true,
+									},
+									"currency": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"time_period": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceGlesysTemplatesRead(ctx context.Context, d *schema.ResourceData, m any) diag.Diagnostics {
+	client := m.(*apiClient)
+
+	templates, err := client.serverTemplates(ctx)
+	if err != nil {
+		return diag.Errorf("Error retrieving templates: %s", err)
+	}
+
+	platform := d.Get("platform").(string)
+	var platforms []string
+	for p := range templates {
+		platforms = append(platforms, p)
+	}
+	sort.Strings(platforms)
+	if platform != "" && !slices.ContainsFunc(platforms, func(p string) bool { return strings.EqualFold(p, platform) }) {
+		return diag.Errorf("platform: %q is not a valid platform, valid values are: %s", platform, strings.Join(platforms, ", "))
+	}
+
+	var nameRegex *regexp.Regexp
+	if v, ok := d.GetOk("name_regex"); ok {
+		nameRegex = regexp.MustCompile(v.(string))
+	}
+	tag := d.Get("tag").(string)
+
+	var matches []serverTemplate
+	for _, p := range platforms {
+		if platform != "" && !strings.EqualFold(p, platform) {
+			continue
+		}
+		for _, t := range templates[p] {
+			if tag != "" && !slices.Contains(t.CurrentTags, tag) {
+				continue
+			}
+			if nameRegex != nil && !nameRegex.MatchString(t.Name) {
+				continue
+			}
+			if t.Platform == "" {
+				t.Platform = p
+			}
+			matches = append(matches, t)
+		}
+	}
+
+	if d.Get("most_recent").(bool) {
+		if len(matches) == 0 {
+			return diag.Errorf("no template matches the filters")
+		}
+		latest := matches[0]
+		for _, t := range matches[1:] {
+			if compareVersionedNames(t.Name, latest.Name) > 0 {
+				latest = t
+			}
+		}
+		matches = []serverTemplate{latest}
+	}
+
+	var ids []string
+	list := make([]map[string]interface{}, 0, len(matches))
+	for _, t := range matches {
+		ids = append(ids, t.ID)
+		list = append(list, map[string]interface{}{
+			"id":           t.ID,
+			"name":         t.Name,
+			"platform":     t.Platform,
+			"os":           t.OS,
+			"current_tags": t.CurrentTags,
+			"min_disk":     t.MinDiskSize,
+			"min_memory":   t.MinMemSize,
+			"instance_cost": []map[string]interface{}{
+				{
+					"amount":      t.InstanceCost.Amount,
+					"currency":    t.InstanceCost.Currency,
+					"time_period": t.InstanceCost.Timeperiod,
+				},
+			},
+		})
+	}
+
+	if err := d.Set("templates", list); err != nil {
+		return diag.Errorf("unable to set templates, read value %v", err)
+	}
+	d.SetId(strconv.Itoa(schema.HashString(strings.Join(ids, ","))))
+
+	return nil
+}
+
+// compareVersionedNames compares template names with the numbers in them
+// compared by value, so that "Debian 12" sorts after "Debian 9" and
+// "Ubuntu 24.04" after "Ubuntu 22.04".
+func compareVersionedNames(a, b string) int {
+	ac, bc := splitVersionedName(a), splitVersionedName(b)
+	for i := 0; i < len(ac) && i < len(bc); i++ {
+		an, aErr := strconv.Atoi(ac[i])
+		bn, bErr := strconv.Atoi(bc[i])
+		switch {
+		case aErr == nil && bErr == nil:
+			if an != bn {
+				return an - bn
+			}
+		case ac[i] != bc[i]:
+			return strings.Compare(ac[i], bc[i])
+		}
+	}
+	return len(ac) - len(bc)
+}
+
+// splitVersionedName splits name into runs of digits and runs of other
+// characters.
+func splitVersionedName(name string) []string {
+	var chunks []string
+	start := 0
+	for i, r := range name {
+		if i > start && unicode.IsDigit(r) != unicode.IsDigit(rune(name[start])) {
+			chunks = append(chunks, name[start:i])
+			start = i
+		}
+	}
+	if start < len(name) {
+		chunks = append(chunks, name[start:])
+	}
+	return chunks
+}
@@ -0,0 +1,96 @@
+package glesys
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestDataSourceGlesysTemplatesRead(t *testing.T) {
+	_, client := newFakeClient(t)
+
+	for _, tt := range []struct {
+		name    string
+		config  map[string]interface{}
+		want    []string
+		wantErr string
+	}{
+		{
+			name: "all",
+			want: []string{"Debian 12 (Bookworm)", "Ubuntu 24.04 LTS (Noble Numbat)", "Debian 12 64-bit"},
+		},
+		{
+			name:   "platform",
+			config: map[string]interface{}{"platform": "vmware"},
+			want:   []string{"Debian 12 64-bit"},
+		},
+		{
+			name:   "tag",
+			config: map[string]interface{}{"tag": "ubuntu-lts"},
+			want:   []string{"Ubuntu 24.04 LTS (Noble Numbat)"},
+		},
+		{
+			name:   "name_regex",
+			config: map[string]interface{}{"platform": "KVM", "name_regex": "^Debian"},
+			want:   []string{"Debian 12 (Bookworm)"},
+		},
+		{
+			name:   "most_recent",
+			config: map[string]interface{}{"platform": "KVM", "most_recent": true},
+			want:   []string{"Ubuntu 24.04 LTS (Noble Numbat)"},
+		},
+		{
+			name:    "most_recent_no_match",
+			config:  map[string]interface{}{"tag": "centos", "most_recent": true},
+			wantErr: "no template matches the filters",
+		},
+		{
+			name:    "invalid_platform",
+			config:  map[string]interface{}{"platform": "Xen"},
+			wantErr: "platform: \"Xen\" is not a valid platform, valid values are: KVM, VMware",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, dataSourceGlesysTemplates().Schema, tt.config)
+
+			diags := dataSourceGlesysTemplatesRead(context.Background(), d, client)
+			if tt.wantErr != "" {
+				if !diags.HasError() || !strings.Contains(diags[0].Summary, tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, diags)
+				}
+				return
+			}
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+
+			var got []string
+			for _, v := range d.Get("templates").([]interface{}) {
+				got = append(got, v.(map[string]interface{})["name"].(string))
+			}
+			if strings.Join(got, "|") != strings.Join(tt.want, "|") {
+				t.Errorf("got templates %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_compareVersionedNames(t *testing.T) {
+	for _, tt := range []struct {
+		a, b string
+		want int
+	}{
+		{"Debian 12", "Debian 9", 1},
+		{"Ubuntu 22.04 LTS", "Ubuntu 24.04 LTS", -1},
+		{"Ubuntu 24.04 LTS", "Ubuntu 24.04 LTS", 0},
+		{"Debian 11", "Debian 11 (Bullseye)", -1},
+		{"Windows Server 2022", "Windows Server 2019 Datacenter", 1},
+	} {
+		got := compareVersionedNames(tt.a, tt.b)
+		if (got > 0) != (tt.want > 0) || (got < 0) != (tt.want < 0) {
+			t.Errorf("compareVersionedNames(%q, %q) = %d, want sign of %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
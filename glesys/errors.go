@@ -0,0 +1,62 @@
+package glesys
+
+import (
+	"log"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// glesys-go reports failed API calls as plain errors on the form
+// "Request failed with HTTP error: 404 (Server does not exist)".
+var apiErrorRegexp = regexp.MustCompile(`HTTP error: (\d{3}) \((.*)\)$`)
+
+// apiError is the status code and status text of a failed API call.
+type apiError struct {
+	StatusCode int
+	Text       string
+}
+
+// parseAPIError extracts the API status from an error returned by glesys-go.
+// Errors that never reached the API, like timeouts, are not API errors.
+func parseAPIError(err error) (*apiError, bool) {
+	if err == nil {
+		return nil, false
+	}
+
+	match := apiErrorRegexp.FindStringSubmatch(err.Error())
+	if match == nil {
+		return nil, false
+	}
+
+	code, _ := strconv.Atoi(match[1])
+	return &apiError{StatusCode: code, Text: match[2]}, true
+}
+
+// isNotFoundError reports whether err means that the object no longer exists.
+func isNotFoundError(err error) bool {
+	apiErr, ok := parseAPIError(err)
+	if !ok {
+		return false
+	}
+
+	return apiErr.StatusCode == http.StatusNotFound ||
+		strings.Contains(strings.ToLower(apiErr.Text), "does not exist")
+}
+
+// readError handles an error from the API call in a Read function. The
+// resource is only removed from state when the API says it is gone, any other
+// error is returned so that Terraform doesn't plan to recreate it.
+func readError(d *schema.ResourceData, err error, resource string) diag.Diagnostics {
+	if isNotFoundError(err) {
+		log.Printf("[WARN] %s (%s) not found, removing from state: %s", resource, d.Id(), err)
+		d.SetId("")
+		return nil
+	}
+
+	return diag.Errorf("Error reading %s (%s): %s", resource, d.Id(), err)
+}
@@ -0,0 +1,83 @@
+package glesys
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func Test_isNotFoundError(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{
+			name: "nil",
+			err:  nil,
+			want: false,
+		},
+		{
+			name: "404",
+			err:  errors.New("Request failed with HTTP error: 404 (Not Found)"),
+			want: true,
+		},
+		{
+			name: "does_not_exist",
+			err:  errors.New("Request failed with HTTP error: 400 (The server kvm123 does not exist)"),
+			want: true,
+		},
+		{
+			name: "wrapped_404",
+			err:  fmt.Errorf("fetching server: %w", errors.New("Request failed with HTTP error: 404 (Server does not exist)")),
+			want: true,
+		},
+		{
+			name: "server_error",
+			err:  errors.New("Request failed with HTTP error: 500 (Internal Server Error)"),
+			want: false,
+		},
+		{
+			name: "locked",
+			err:  errors.New("Request failed with HTTP error: 400 (Server is locked)"),
+			want: false,
+		},
+		{
+			name: "transport_error",
+			err:  errors.New("Post \"https://api.glesys.com/server/details\": context deadline exceeded"),
+			want: false,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isNotFoundError(tt.err); got != tt.want {
+				t.Errorf("got: %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_readError(t *testing.T) {
+	_, client := newFakeClient(t)
+
+	_, err := client.Servers.Details(context.Background(), "kvm404")
+	d := schema.TestResourceDataRaw(t, resourceGlesysServer().Schema, map[string]interface{}{})
+	d.SetId("kvm404")
+	if diags := readError(d, err, "server"); diags.HasError() {
+		t.Fatalf("expected missing server to be removed from state, got %s", diagnosticsToString(diags))
+	}
+	if d.Id() != "" {
+		t.Fatalf("expected id to be cleared, got %q", d.Id())
+	}
+
+	d.SetId("kvm500")
+	diags := readError(d, errors.New("Request failed with HTTP error: 500 (Internal Server Error)"), "server")
+	if !diags.HasError() {
+		t.Fatal("expected error diagnostic for server error")
+	}
+	if d.Id() != "kvm500" {
+		t.Fatalf("expected id to be kept, got %q", d.Id())
+	}
+}
@@ -0,0 +1,2765 @@
+package glesys
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/glesys/glesys-go/v8"
+)
+
+// fakeGlesysAPI is an in-process stand-in for the GleSYS API. It keeps the
+// state for every object the provider manages, so the acceptance tests can run
+// the full resource lifecycle without credentials or network access.
+type fakeGlesysAPI struct {
+	*httptest.Server
+
+	mu sync.Mutex
+	id int
+
+	// calls counts the requests made to each endpoint.
+	calls map[string]int
+
+	servers         map[string]*glesys.ServerDetails
+	networkAdapters map[string]*glesys.NetworkAdapter
+	networks        map[string]*glesys.Network
+	ips             map[string]*glesys.IP
+	domains         map[string]*glesys.DNSDomain
+	records         map[int]*glesys.DNSDomainRecord
+	emailAccounts   map[string]*glesys.EmailAccount
+	emailAliases    map[string]*glesys.EmailAlias
+	databases       map[string]*glesys.DatabaseDetails
+	loadBalancers   map[string]*glesys.LoadBalancerDetails
+	objectStorages  map[string]*glesys.ObjectStorageInstance
+	privateNetworks map[string]*glesys.PrivateNetwork
+	segments        map[string]*fakeSegment
+	serverLimits    map[string]map[string]*serverLimit
+
+	// ipOrder records when each IP was added to its server, the API lists the
+	// addresses of a server in that order.
+	ipOrder map[string]int
+
+	// failures holds errors to return from the next call to an endpoint,
+	// instead of calling its handler.
+	failures map[string]*fakeError
+
+	// reboots holds the servers being rebooted, and how far the reboot has
+	// come, see serverDetails.
+	reboots map[string]int
+
+	// quickReboots makes reboots finish before the next poll, so that the
+	// server is never seen going down.
+	quickReboots bool
+}
+
+type fakeSegment struct {
+	glesys.PrivateNetworkSegment
+	privateNetworkID string
+}
+
+// fakeRequest holds the parameters of a single API call. GleSYS accepts
+// parameters both as JSON in the body and as key/value pairs in the path.
+type fakeRequest struct {
+	endpoint string
+	args     map[string]string
+	body     []byte
+}
+
+// fakeError is returned by handlers to produce a GleSYS style error response.
+type fakeError struct {
+	code int
+	text string
+}
+
+func (e *fakeError) Error() string {
+	return e.text
+}
+
+func fakeNotFound(format string, a ...interface{}) *fakeError {
+	return &fakeError{code: http.StatusNotFound, text: fmt.Sprintf(format, a...)}
+}
+
+func fakeBadRequest(format string, a ...interface{}) *fakeError {
+	return &fakeError{code: http.StatusBadRequest, text: fmt.Sprintf(format, a...)}
+}
+
+// fakeTemplates is the template list returned by server/templates.
+var fakeTemplates = glesys.ServerPlatformTemplates{
+	KVM: []glesys.ServerPlatformTemplateDetails{
+		{
+			ID:           "1a0e5a3c-0f2d-4b8e-9d57-0c1d5c0b1f01",
+			Name:         "Debian 12 (Bookworm)",
+			OS:           "linux",
+			Platform:     "KVM",
+			MinDiskSize:  5,
+			MinMemSize:   512,
+			InstanceCost: glesys.ServerTemplateInstanceCost{Amount: 0, Currency: "SEK", Timeperiod: "month"},
+		},
+		{
+			ID:           "fc5d38f7-4c9d-4920-a3a0-3252f71fe2c5",
+			Name:         "Ubuntu 24.04 LTS (Noble Numbat)",
+			OS:           "linux",
+			Platform:     "KVM",
+			MinDiskSize:  5,
+			MinMemSize:   512,
+			InstanceCost: glesys.ServerTemplateInstanceCost{Amount: 0, Currency: "SEK", Timeperiod: "month"},
+		},
+	},
+	VMware: []glesys.ServerPlatformTemplateDetails{
+		{
+			ID:           "Debian 12 64-bit",
+			Name:         "Debian 12 64-bit",
+			OS:           "linux",
+			Platform:     "VMware",
+			MinDiskSize:  5,
+			MinMemSize:   512,
+			InstanceCost: glesys.ServerTemplateInstanceCost{Amount: 0, Currency: "SEK", Timeperiod: "month"},
+		},
+	},
+}
+
+// fakeTemplateTags maps KVM template IDs to the tags they currently carry.
+var fakeTemplateTags = map[string][]string{
+	"1a0e5a3c-0f2d-4b8e-9d57-0c1d5c0b1f01": {"debian", "debian-12"},
+	"fc5d38f7-4c9d-4920-a3a0-3252f71fe2c5": {"ubuntu", "ubuntu-lts", "ubuntu-24-04"},
+}
+
+func newFakeGlesysAPI() *fakeGlesysAPI {
+	f := &fakeGlesysAPI{
+		calls:           map[string]int{},
+		servers:         map[string]*glesys.ServerDetails{},
+		networkAdapters: map[string]*glesys.NetworkAdapter{},
+		networks:        map[string]*glesys.Network{},
+		ips:             map[string]*glesys.IP{},
+		domains:         map[string]*glesys.DNSDomain{},
+		records:         map[int]*glesys.DNSDomainRecord{},
+		emailAccounts:   map[string]*glesys.EmailAccount{},
+		emailAliases:    map[string]*glesys.EmailAlias{},
+		databases:       map[string]*glesys.DatabaseDetails{},
+		loadBalancers:   map[string]*glesys.LoadBalancerDetails{},
+		objectStorages:  map[string]*glesys.ObjectStorageInstance{},
+		privateNetworks: map[string]*glesys.PrivateNetwork{},
+		segments:        map[string]*fakeSegment{},
+		serverLimits:    map[string]map[string]*serverLimit{},
+		ipOrder:         map[string]int{},
+		failures:        map[string]*fakeError{},
+		reboots:         map[string]int{},
+	}
+	f.Server = httptest.NewServer(http.HandlerFunc(f.serveHTTP))
+	return f
+}
+
+// newClient returns a provider client talking to the fake API.
+func (f *fakeGlesysAPI) newClient() *apiClient {
+	config := Config{UserID: "cl12345", Token: "fake-token", APIEndpoint: f.URL}
+	client, err := config.Client()
+	if err != nil {
+		panic(err)
+	}
+	client.waitPollInterval = 10 * time.Millisecond
+	return client
+}
+
+// newFakeClient starts a fake API for the test and returns it along with a
+// client talking to it. The API is closed once the test and its subtests are
+// done.
+func newFakeClient(t *testing.T) (*fakeGlesysAPI, *apiClient) {
+	t.Helper()
+
+	api := newFakeGlesysAPI()
+	t.Cleanup(api.Close)
+	return api, api.newClient()
+}
+
+// mergeConfig returns a copy of config with changes applied. A nil value
+// removes the attribute.
+func mergeConfig(config map[string]interface{}, changes map[string]interface{}) map[string]interface{} {
+	merged := map[string]interface{}{}
+	for k, v := range config {
+		merged[k] = v
+	}
+	for k, v := range changes {
+		if v == nil {
+			delete(merged, k)
+			continue
+		}
+		merged[k] = v
+	}
+	return merged
+}
+
+func (f *fakeGlesysAPI) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if user, pass, ok := r.BasicAuth(); !ok || user == "" || pass == "" {
+		f.writeError(w, &fakeError{code: http.StatusUnauthorized, text: "Authentication failed"})
+		return
+	}
+
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(parts) < 2 {
+		f.writeError(w, fakeNotFound("Unknown endpoint %s", r.URL.Path))
+		return
+	}
+
+	req := &fakeRequest{
+		endpoint: parts[0] + "/" + parts[1],
+		args:     map[string]string{},
+	}
+	for i := 2; i+1 < len(parts); i += 2 {
+		req.args[parts[i]] = parts[i+1]
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		f.writeError(w, fakeBadRequest("could not read request: %s", err))
+		return
+	}
+	req.body = body
+
+	handler, ok := fakeHandlers[req.endpoint]
+	if !ok {
+		f.writeError(w, fakeNotFound("Unknown endpoint %s", req.endpoint))
+		return
+	}
+
+	f.mu.Lock()
+	f.calls[req.endpoint]++
+	key, result, ferr := "", interface{}(nil), f.failures[req.endpoint]
+	if ferr != nil {
+		delete(f.failures, req.endpoint)
+	} else {
+		key, result, ferr = handler(f, req)
+	}
+	f.mu.Unlock()
+
+	if ferr != nil {
+		f.writeError(w, ferr)
+		return
+	}
+
+	response := map[string]interface{}{
+		"status": map[string]interface{}{"code": http.StatusOK, "text": "OK"},
+	}
+	if key != "" {
+		response[key] = result
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{"response": response})
+}
+
+func (f *fakeGlesysAPI) writeError(w http.ResponseWriter, ferr *fakeError) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(ferr.code)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"response": map[string]interface{}{
+			"status": map[string]interface{}{"code": ferr.code, "text": ferr.text},
+		},
+	})
+}
+
+func (f *fakeGlesysAPI) nextID(prefix string) string {
+	f.id++
+	return fmt.Sprintf("%s%d", prefix, f.id)
+}
+
+// decode unmarshals the request body into v.
+func (req *fakeRequest) decode(v interface{}) *fakeError {
+	if len(req.body) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(req.body, v); err != nil {
+		return fakeBadRequest("invalid parameters for %s: %s", req.endpoint, err)
+	}
+	return nil
+}
+
+// str returns a string parameter from the path or the request body.
+func (req *fakeRequest) str(key string) string {
+	if v, ok := req.args[key]; ok {
+		return v
+	}
+	params := map[string]interface{}{}
+	if len(req.body) > 0 {
+		json.Unmarshal(req.body, &params)
+	}
+	switch v := params[key].(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	}
+	return ""
+}
+
+type fakeHandler func(f *fakeGlesysAPI, req *fakeRequest) (string, interface{}, *fakeError)
+
+var fakeHandlers = map[string]fakeHandler{
+	"server/create":             (*fakeGlesysAPI).serverCreate,
+	"server/details":            (*fakeGlesysAPI).serverDetails,
+	"server/edit":               (*fakeGlesysAPI).serverEdit,
+	"server/destroy":            (*fakeGlesysAPI).serverDestroy,
+	"server/list":               (*fakeGlesysAPI).serverList,
+	"server/networkadapters":    (*fakeGlesysAPI).serverNetworkAdapters,
+	"server/templates":          (*fakeGlesysAPI).serverTemplates,
+	"server/allowedarguments":   (*fakeGlesysAPI).serverAllowedArguments,
+	"server/estimatedcost":      (*fakeGlesysAPI).serverEstimatedCost,
+	"server/previewcloudconfig": (*fakeGlesysAPI).serverPreviewCloudConfig,
+	"server/console":            (*fakeGlesysAPI).serverConsole,
+	"server/limits":             (*fakeGlesysAPI).serverLimitsList,
+	"server/resetlimit":         (*fakeGlesysAPI).serverResetLimit,
+	"server/listiso":            (*fakeGlesysAPI).serverListISO,
+	"server/listbackups":        (*fakeGlesysAPI).serverListBackups,
+	"server/clone":              (*fakeGlesysAPI).serverClone,
+	"server/mountiso":           (*fakeGlesysAPI).serverMountISO,
+	"server/start":              (*fakeGlesysAPI).serverStart,
+	"server/status":             (*fakeGlesysAPI).serverStatus,
+	"server/stop":               (*fakeGlesysAPI).serverStop,
+
+	"serverdisk/create":        (*fakeGlesysAPI).serverDiskCreate,
+	"serverdisk/updatename":    (*fakeGlesysAPI).serverDiskEdit,
+	"serverdisk/reconfigure":   (*fakeGlesysAPI).serverDiskEdit,
+	"serverdisk/delete":        (*fakeGlesysAPI).serverDiskDelete,
+	"serverdisk/limits":        (*fakeGlesysAPI).serverDiskLimits,
+	"serverdisk/estimatedcost": (*fakeGlesysAPI).serverDiskEstimatedCost,
+
+	"networkadapter/create":  (*fakeGlesysAPI).networkAdapterCreate,
+	"networkadapter/details": (*fakeGlesysAPI).networkAdapterDetails,
+	"networkadapter/edit":    (*fakeGlesysAPI).networkAdapterEdit,
+	"networkadapter/delete":  (*fakeGlesysAPI).networkAdapterDelete,
+
+	"network/create":  (*fakeGlesysAPI).networkCreate,
+	"network/details": (*fakeGlesysAPI).networkDetails,
+	"network/edit":    (*fakeGlesysAPI).networkEdit,
+	"network/delete":  (*fakeGlesysAPI).networkDelete,
+	"network/list":    (*fakeGlesysAPI).networkList,
+
+	"ip/listfree": (*fakeGlesysAPI).ipListFree,
+	"ip/listown":  (*fakeGlesysAPI).ipListOwn,
+	"ip/take":     (*fakeGlesysAPI).ipTake,
+	"ip/release":  (*fakeGlesysAPI).ipRelease,
+	"ip/details":  (*fakeGlesysAPI).ipDetails,
+	"ip/setptr":   (*fakeGlesysAPI).ipSetPTR,
+	"ip/resetptr": (*fakeGlesysAPI).ipResetPTR,
+	"ip/add":      (*fakeGlesysAPI).ipAdd,
+	"ip/remove":   (*fakeGlesysAPI).ipRemove,
+
+	"domain/add":          (*fakeGlesysAPI).domainAdd,
+	"domain/details":      (*fakeGlesysAPI).domainDetails,
+	"domain/edit":         (*fakeGlesysAPI).domainEdit,
+	"domain/delete":       (*fakeGlesysAPI).domainDelete,
+	"domain/list":         (*fakeGlesysAPI).domainList,
+	"domain/listrecords":  (*fakeGlesysAPI).domainListRecords,
+	"domain/addrecord":    (*fakeGlesysAPI).domainAddRecord,
+	"domain/updaterecord": (*fakeGlesysAPI).domainUpdateRecord,
+	"domain/deleterecord": (*fakeGlesysAPI).domainDeleteRecord,
+
+	"email/createaccount": (*fakeGlesysAPI).emailCreateAccount,
+	"email/editaccount":   (*fakeGlesysAPI).emailEditAccount,
+	"email/createalias":   (*fakeGlesysAPI).emailCreateAlias,
+	"email/editalias":     (*fakeGlesysAPI).emailEditAlias,
+	"email/list":          (*fakeGlesysAPI).emailList,
+	"email/delete":        (*fakeGlesysAPI).emailDelete,
+
+	"database/create":            (*fakeGlesysAPI).databaseCreate,
+	"database/details":           (*fakeGlesysAPI).databaseDetails,
+	"database/connectiondetails": (*fakeGlesysAPI).databaseConnectionDetails,
+	"database/updateallowlist":   (*fakeGlesysAPI).databaseUpdateAllowlist,
+	"database/delete":            (*fakeGlesysAPI).databaseDelete,
+	"database/list":              (*fakeGlesysAPI).databaseList,
+	"database/listplans":         (*fakeGlesysAPI).databaseListPlans,
+	"database/estimatedcost":     (*fakeGlesysAPI).databaseEstimatedCost,
+
+	"loadbalancer/create":         (*fakeGlesysAPI).loadBalancerCreate,
+	"loadbalancer/details":        (*fakeGlesysAPI).loadBalancerDetails,
+	"loadbalancer/edit":           (*fakeGlesysAPI).loadBalancerEdit,
+	"loadbalancer/destroy":        (*fakeGlesysAPI).loadBalancerDestroy,
+	"loadbalancer/list":           (*fakeGlesysAPI).loadBalancerList,
+	"loadbalancer/addbackend":     (*fakeGlesysAPI).loadBalancerAddBackend,
+	"loadbalancer/editbackend":    (*fakeGlesysAPI).loadBalancerEditBackend,
+	"loadbalancer/removebackend":  (*fakeGlesysAPI).loadBalancerRemoveBackend,
+	"loadbalancer/addfrontend":    (*fakeGlesysAPI).loadBalancerAddFrontend,
+	"loadbalancer/editfrontend":   (*fakeGlesysAPI).loadBalancerEditFrontend,
+	"loadbalancer/removefrontend": (*fakeGlesysAPI).loadBalancerRemoveFrontend,
+	"loadbalancer/addtarget":      (*fakeGlesysAPI).loadBalancerAddTarget,
+	"loadbalancer/edittarget":     (*fakeGlesysAPI).loadBalancerEditTarget,
+	"loadbalancer/enabletarget":   (*fakeGlesysAPI).loadBalancerEnableTarget,
+	"loadbalancer/disabletarget":  (*fakeGlesysAPI).loadBalancerDisableTarget,
+	"loadbalancer/removetarget":   (*fakeGlesysAPI).loadBalancerRemoveTarget,
+
+	"objectstorage/createinstance":   (*fakeGlesysAPI).objectStorageCreateInstance,
+	"objectstorage/instancedetails":  (*fakeGlesysAPI).objectStorageInstanceDetails,
+	"objectstorage/editinstance":     (*fakeGlesysAPI).objectStorageEditInstance,
+	"objectstorage/deleteinstance":   (*fakeGlesysAPI).objectStorageDeleteInstance,
+	"objectstorage/listinstances":    (*fakeGlesysAPI).objectStorageListInstances,
+	"objectstorage/createcredential": (*fakeGlesysAPI).objectStorageCreateCredential,
+	"objectstorage/deletecredential": (*fakeGlesysAPI).objectStorageDeleteCredential,
+
+	"privatenetwork/create":        (*fakeGlesysAPI).privateNetworkCreate,
+	"privatenetwork/details":       (*fakeGlesysAPI).privateNetworkDetails,
+	"privatenetwork/list":          (*fakeGlesysAPI).privateNetworkList,
+	"privatenetwork/edit":          (*fakeGlesysAPI).privateNetworkEdit,
+	"privatenetwork/delete":        (*fakeGlesysAPI).privateNetworkDelete,
+	"privatenetwork/createsegment": (*fakeGlesysAPI).privateNetworkCreateSegment,
+	"privatenetwork/editsegment":   (*fakeGlesysAPI).privateNetworkEditSegment,
+	"privatenetwork/listsegments":  (*fakeGlesysAPI).privateNetworkListSegments,
+	"privatenetwork/deletesegment": (*fakeGlesysAPI).privateNetworkDeleteSegment,
+}
+
+// Servers
+
+func (f *fakeGlesysAPI) server(id string) (*glesys.ServerDetails, *fakeError) {
+	srv, ok := f.servers[id]
+	if !ok {
+		return nil, fakeNotFound("Server %s does not exist", id)
+	}
+	return srv, nil
+}
+
+// serverCopy returns the server as the API presents it, with a fresh copy of
+// the slices so that later changes to the state don't leak into responses.
+func (f *fakeGlesysAPI) serverCopy(srv *glesys.ServerDetails) glesys.ServerDetails {
+	out := *srv
+	out.AdditionalDisks = append([]glesys.ServerDiskDetails(nil), srv.AdditionalDisks...)
+	out.IPList = nil
+	for _, ip := range f.ips {
+		if ip.ServerID == srv.ID {
+			version := 4
+			if ip.IsIPv6() {
+				version = 6
+			}
+			out.IPList = append(out.IPList, glesys.ServerIP{Address: ip.Address, Version: version})
+		}
+	}
+	sort.Slice(out.IPList, func(i, j int) bool {
+		return f.ipOrder[out.IPList[i].Address] < f.ipOrder[out.IPList[j].Address]
+	})
+	return out
+}
+
+func (f *fakeGlesysAPI) findTemplate(platform, name string) (glesys.ServerPlatformTemplateDetails, bool) {
+	templates := fakeTemplates.KVM
+	if platform == "VMware" {
+		templates = fakeTemplates.VMware
+	}
+	for _, t := range templates {
+		if t.ID == name || t.Name == name {
+			return t, true
+		}
+		for _, tag := range fakeTemplateTags[t.ID] {
+			if tag == name {
+				return t, true
+			}
+		}
+	}
+	return glesys.ServerPlatformTemplateDetails{}, false
+}
+
+func (f *fakeGlesysAPI) serverCreate(req *fakeRequest) (string, interface{}, *fakeError) {
+	var params glesys.CreateServerParams
+	if err := req.decode(&params); err != nil {
+		return "", nil, err
+	}
+
+	template, ok := f.findTemplate(params.Platform, params.Template)
+	if !ok {
+		return "", nil, fakeBadRequest("Template %s is not available on %s", params.Template, params.Platform)
+	}
+
+	prefix := "kvm"
+	if params.Platform == "VMware" {
+		prefix = "wps"
+	}
+
+	srv := &glesys.ServerDetails{
+		ID:          f.nextID(prefix),
+		Bandwidth:   params.Bandwidth,
+		CPU:         params.CPU,
+		DataCenter:  params.DataCenter,
+		Description: params.Description,
+		Hostname:    params.Hostname,
+		InitialTemplate: glesys.ServerTemplateDetails{
+			ID:          template.ID,
+			Name:        template.Name,
+			CurrentTags: fakeTemplateTags[template.ID],
+		},
+		IsRunning: true,
+		Memory:    params.Memory,
+		Platform:  params.Platform,
+		State:     "running",
+		Storage:   params.Storage,
+		Template:  template.Name,
+	}
+	if len(params.Backup) > 0 {
+		srv.Backup = glesys.ServerBackupDetails{Enabled: "yes", Schedules: params.Backup}
+	}
+
+	if err := f.addServer(srv, params.IPv4, params.IPv6); err != nil {
+		return "", nil, err
+	}
+	return "server", f.serverCopy(srv), nil
+}
+
+// addServer assigns the addresses to a new server and stores it along with
+// its primary network adapter.
+func (f *fakeGlesysAPI) addServer(srv *glesys.ServerDetails, ipv4 string, ipv6 string) *fakeError {
+	for version, address := range map[int]string{4: ipv4, 6: ipv6} {
+		if err := f.assignServerIP(srv, version, address); err != nil {
+			return err
+		}
+	}
+
+	f.servers[srv.ID] = srv
+
+	adapter := &glesys.NetworkAdapter{
+		ID:          f.nextID("na"),
+		AdapterType: "VMXNET 3",
+		Bandwidth:   srv.Bandwidth,
+		IsPrimary:   true,
+		Name:        "Network adapter 1",
+		NetworkID:   "internet-" + strings.ToLower(srv.DataCenter),
+		ServerID:    srv.ID,
+		State:       "ready",
+	}
+	f.networkAdapters[adapter.ID] = adapter
+
+	return nil
+}
+
+func (f *fakeGlesysAPI) assignServerIP(srv *glesys.ServerDetails, version int, address string) *fakeError {
+	switch address {
+	case "", "none":
+		return nil
+	case "any":
+		ip := f.newIP(srv.DataCenter, srv.Platform, version)
+		ip.Reserved = "yes"
+		f.attachIP(ip, srv)
+		return nil
+	}
+
+	ip, ok := f.ips[address]
+	if !ok || ip.Reserved != "yes" {
+		return fakeBadRequest("IP %s is not reserved by this project", address)
+	}
+	if ip.ServerID != "" {
+		return fakeBadRequest("IP %s is already in use by %s", address, ip.ServerID)
+	}
+	f.attachIP(ip, srv)
+	return nil
+}
+
+func (f *fakeGlesysAPI) attachIP(ip *glesys.IP, srv *glesys.ServerDetails) {
+	ip.ServerID = srv.ID
+	f.id++
+	f.ipOrder[ip.Address] = f.id
+}
+
+func (f *fakeGlesysAPI) serverDetails(req *fakeRequest) (string, interface{}, *fakeError) {
+	srv, err := f.server(req.str("serverid"))
+	if err != nil {
+		return "", nil, err
+	}
+
+	// A reboot takes a few polls, like in the real API. The server is still
+	// running on the first two polls, locked and stopped on the third and
+	// running again on the fourth.
+	if step, ok := f.reboots[srv.ID]; ok {
+		switch step {
+		case 2:
+			srv.IsRunning = false
+			srv.IsLocked = true
+			srv.State = "locked"
+		case 3:
+			srv.IsRunning = true
+			srv.IsLocked = false
+			srv.State = "running"
+			delete(f.reboots, srv.ID)
+		}
+		if step < 3 {
+			f.reboots[srv.ID] = step + 1
+		}
+	}
+	return "server", f.serverCopy(srv), nil
+}
+
+func (f *fakeGlesysAPI) serverEdit(req *fakeRequest) (string, interface{}, *fakeError) {
+	var params struct {
+		glesys.EditServerParams
+		ServerID string `json:"serverid"`
+	}
+	if err := req.decode(&params); err != nil {
+		return "", nil, err
+	}
+
+	srv, err := f.server(params.ServerID)
+	if err != nil {
+		return "", nil, err
+	}
+	if srv.IsLocked {
+		return "", nil, fakeBadRequest("Server %s is locked", srv.ID)
+	}
+
+	if params.Bandwidth != 0 {
+		srv.Bandwidth = params.Bandwidth
+	}
+	if params.CPU != 0 {
+		srv.CPU = params.CPU
+	}
+	if params.Description != "" {
+		srv.Description = params.Description
+	}
+	if params.Hostname != "" {
+		srv.Hostname = params.Hostname
+	}
+	if params.Memory != 0 {
+		srv.Memory = params.Memory
+	}
+	if params.Storage != 0 {
+		srv.Storage = params.Storage
+	}
+	if params.Backup != nil {
+		srv.Backup = glesys.ServerBackupDetails{Enabled: "yes", Schedules: params.Backup}
+	}
+
+	return "server", f.serverCopy(srv), nil
+}
+
+func (f *fakeGlesysAPI) serverDestroy(req *fakeRequest) (string, interface{}, *fakeError) {
+	var params struct {
+		glesys.DestroyServerParams
+		ServerID string `json:"serverid"`
+	}
+	if err := req.decode(&params); err != nil {
+		return "", nil, err
+	}
+
+	if _, err := f.server(params.ServerID); err != nil {
+		return "", nil, err
+	}
+
+	for _, ip := range f.ips {
+		if ip.ServerID == params.ServerID {
+			ip.ServerID = ""
+			if !params.KeepIP {
+				ip.Reserved = "no"
+			}
+		}
+	}
+	for id, adapter := range f.networkAdapters {
+		if adapter.ServerID == params.ServerID {
+			delete(f.networkAdapters, id)
+		}
+	}
+	delete(f.servers, params.ServerID)
+
+	return "", nil, nil
+}
+
+func (f *fakeGlesysAPI) serverList(req *fakeRequest) (string, interface{}, *fakeError) {
+	servers := []glesys.Server{}
+	for _, srv := range f.servers {
+		servers = append(servers, glesys.Server{
+			DataCenter: srv.DataCenter,
+			Hostname:   srv.Hostname,
+			ID:         srv.ID,
+			Platform:   srv.Platform,
+		})
+	}
+	return "servers", servers, nil
+}
+
+func (f *fakeGlesysAPI) serverNetworkAdapters(req *fakeRequest) (string, interface{}, *fakeError) {
+	serverID := req.str("serverid")
+	if _, err := f.server(serverID); err != nil {
+		return "", nil, err
+	}
+
+	adapters := []glesys.NetworkAdapter{}
+	for _, adapter := range f.networkAdapters {
+		if adapter.ServerID == serverID {
+			adapters = append(adapters, *adapter)
+		}
+	}
+	return "networkadapters", adapters, nil
+}
+
+func (f *fakeGlesysAPI) serverTemplates(req *fakeRequest) (string, interface{}, *fakeError) {
+	templates := map[string][]serverTemplate{}
+	for platform, list := range map[string][]glesys.ServerPlatformTemplateDetails{
+		"KVM":    fakeTemplates.KVM,
+		"VMware": fakeTemplates.VMware,
+	} {
+		templates[platform] = []serverTemplate{}
+		for _, t := range list {
+			templates[platform] = append(templates[platform], serverTemplate{
+				ServerPlatformTemplateDetails: t,
+				CurrentTags:                   fakeTemplateTags[t.ID],
+			})
+		}
+	}
+	return "templates", templates, nil
+}
+
+// fakeAllowedArguments is the argument list returned by
+// server/allowedarguments. Template names are added from fakeTemplates.
+var fakeAllowedArguments = map[string]map[string]interface{}{
+	"KVM": {
+		"datacenter": []string{"Falkenberg", "Stockholm", "Amsterdam", "London", "Oslo"},
+		"cpucores":   []int{1, 2, 4, 6, 8, 12, 16},
+		"memorysize": []int{512, 1024, 2048, 4096, 8192, 16384, 32768},
+		"disksize":   []int{5, 10, 20, 40, 60, 80, 100, 150, 200, 300, 500},
+		"bandwidth":  []int{100, 1000},
+	},
+	"VMware": {
+		"datacenter": []string{"Falkenberg", "Stockholm"},
+		"cpucores":   []int{1, 2, 4, 8},
+		"memorysize": []int{512, 1024, 2048, 4096, 8192},
+		"disksize":   []int{5, 10, 20, 40, 80, 150},
+		"bandwidth":  []int{10, 100, 1000},
+	},
+}
+
+func (f *fakeGlesysAPI) serverAllowedArguments(req *fakeRequest) (string, interface{}, *fakeError) {
+	list := map[string]map[string]interface{}{}
+	for platform, arguments := range fakeAllowedArguments {
+		list[platform] = map[string]interface{}{}
+		for name, values := range arguments {
+			list[platform][name] = values
+		}
+	}
+
+	for platform, templates := range map[string][]glesys.ServerPlatformTemplateDetails{
+		"KVM":    fakeTemplates.KVM,
+		"VMware": fakeTemplates.VMware,
+	} {
+		names := []string{}
+		for _, t := range templates {
+			names = append(names, t.Name)
+		}
+		list[platform]["templatename"] = names
+	}
+
+	return "argumentslist", list, nil
+}
+
+func (f *fakeGlesysAPI) serverEstimatedCost(req *fakeRequest) (string, interface{}, *fakeError) {
+	var params serverEstimatedCostParams
+	if err := req.decode(&params); err != nil {
+		return "", nil, err
+	}
+
+	current := 0.0
+	if params.ServerID != "" {
+		srv, err := f.server(params.ServerID)
+		if err != nil {
+			return "", nil, err
+		}
+		current = fakeServerPrice(srv.CPU, srv.Memory, srv.Storage)
+		if params.CPU == 0 {
+			params.CPU = srv.CPU
+		}
+		if params.Memory == 0 {
+			params.Memory = srv.Memory
+		}
+		if params.Storage == 0 {
+			params.Storage = srv.Storage
+		}
+	}
+
+	estimated := fakeServerPrice(params.CPU, params.Memory, params.Storage)
+	return "billing", fakeBilling(current, estimated), nil
+}
+
+// fakeCloudConfigTag matches mustache variable tags for params, other tags
+// than variables and sections aren't rendered by the fake.
+var fakeCloudConfigTag = regexp.MustCompile(`\{\{\{?\s*&?\s*params\.([A-Za-z0-9_-]+)\s*\}?\}\}`)
+
+// fakeCloudConfigSection matches the opening tag of a section, or an inverted
+// section, for a param. Tags on a line of their own remove the line.
+var fakeCloudConfigSection = regexp.MustCompile(`\{\{\s*([#^])\s*params\.([A-Za-z0-9_-]+)\s*\}\}\n?`)
+
+// renderFakeCloudConfigSections keeps the content of sections for params that
+// are set, and of inverted sections for params that aren't.
+func renderFakeCloudConfigSections(template string, params map[string]any) string {
+	for {
+		open := fakeCloudConfigSection.FindStringSubmatchIndex(template)
+		if open == nil {
+			return template
+		}
+		kind, name := template[open[2]:open[3]], template[open[4]:open[5]]
+
+		closeTag := regexp.MustCompile(`\{\{\s*/\s*params\.` + regexp.QuoteMeta(name) + `\s*\}\}\n?`)
+		end := closeTag.FindStringIndex(template[open[1]:])
+		if end == nil {
+			return template
+		}
+		content := template[open[1] : open[1]+end[0]]
+
+		value, ok := params[name]
+		set := ok && value != nil && value != "" && value != false
+		if set != (kind == "#") {
+			content = ""
+		}
+		template = template[:open[0]] + content + template[open[1]+end[1]:]
+	}
+}
+
+func (f *fakeGlesysAPI) serverPreviewCloudConfig(req *fakeRequest) (string, interface{}, *fakeError) {
+	var params glesys.PreviewCloudConfigParams
+	if err := req.decode(&params); err != nil {
+		return "", nil, err
+	}
+
+	rendered := renderFakeCloudConfigSections(params.CloudConfig, params.CloudConfigParams)
+	preview := fakeCloudConfigTag.ReplaceAllStringFunc(rendered, func(tag string) string {
+		name := fakeCloudConfigTag.FindStringSubmatch(tag)[1]
+		if v, ok := params.CloudConfigParams[name]; ok {
+			return fmt.Sprint(v)
+		}
+		return ""
+	})
+	return "cloudconfig", glesys.CloudConfigPreview{
+		Preview: preview,
+		Context: glesys.PreviewContext{Params: params.CloudConfigParams, Users: params.Users},
+	}, nil
+}
+
+func (f *fakeGlesysAPI) serverConsole(req *fakeRequest) (string, interface{}, *fakeError) {
+	srv, err := f.server(req.str("serverid"))
+	if err != nil {
+		return "", nil, err
+	}
+	return "console", glesys.ServerConsoleDetails{
+		Host:     "console-" + strings.ToLower(srv.DataCenter) + ".example.com",
+		Port:     5900,
+		Password: "console-" + srv.ID,
+		Protocol: "vnc",
+		URL:      "https://console.example.com/" + srv.ID,
+	}, nil
+}
+
+func (f *fakeGlesysAPI) serverStatus(req *fakeRequest) (string, interface{}, *fakeError) {
+	srv, err := f.server(req.str("serverid"))
+	if err != nil {
+		return "", nil, err
+	}
+	status := serverStatus{
+		State:    srv.State,
+		CPU:      serverUsage{Usage: 0.25, Max: float64(srv.CPU), Unit: "cores"},
+		Memory:   serverUsage{Usage: 512, Max: float64(srv.Memory), Unit: "MB"},
+		Disk:     serverUsage{Usage: 4, Max: float64(srv.Storage), Unit: "GB"},
+		Transfer: serverUsage{Usage: 12.5, Max: 1000, Unit: "GB"},
+	}
+	status.Uptime.Current = 3600
+	status.Uptime.Unit = "s"
+	return "server", status, nil
+}
+
+// limits returns the limits of the server, created on first use.
+func (f *fakeGlesysAPI) limits(srv *glesys.ServerDetails) map[string]*serverLimit {
+	if _, ok := f.serverLimits[srv.ID]; !ok {
+		f.serverLimits[srv.ID] = map[string]*serverLimit{
+			"numproc":    {Current: 42, Limit: 1024},
+			"numtcpsock": {Current: 12, Limit: 4096},
+		}
+	}
+	return f.serverLimits[srv.ID]
+}
+
+func (f *fakeGlesysAPI) serverLimitsList(req *fakeRequest) (string, interface{}, *fakeError) {
+	srv, err := f.server(req.str("serverid"))
+	if err != nil {
+		return "", nil, err
+	}
+	return "limits", f.limits(srv), nil
+}
+
+func (f *fakeGlesysAPI) serverResetLimit(req *fakeRequest) (string, interface{}, *fakeError) {
+	srv, err := f.server(req.str("serverid"))
+	if err != nil {
+		return "", nil, err
+	}
+	limit, ok := f.limits(srv)[req.str("type")]
+	if !ok {
+		return "", nil, fakeBadRequest("Unknown limit %s", req.str("type"))
+	}
+	limit.Failcount = 0
+	return "", nil, nil
+}
+
+// fakeISOFiles are the ISO files available to VMware servers.
+var fakeISOFiles = []string{"debian-12-amd64-netinst.iso", "systemrescue-11.00-amd64.iso"}
+
+func (f *fakeGlesysAPI) serverListISO(req *fakeRequest) (string, interface{}, *fakeError) {
+	srv, err := f.server(req.str("serverid"))
+	if err != nil {
+		return "", nil, err
+	}
+	if srv.Platform != "VMware" {
+		return "", nil, fakeBadRequest("ISO files are not supported on %s", srv.Platform)
+	}
+	return "isofiles", fakeISOFiles, nil
+}
+
+func (f *fakeGlesysAPI) serverMountISO(req *fakeRequest) (string, interface{}, *fakeError) {
+	srv, err := f.server(req.str("serverid"))
+	if err != nil {
+		return "", nil, err
+	}
+	if srv.Platform != "VMware" {
+		return "", nil, fakeBadRequest("ISO files are not supported on %s", srv.Platform)
+	}
+	if srv.IsLocked {
+		return "", nil, fakeBadRequest("Server %s is locked", srv.ID)
+	}
+
+	isoFile := req.str("isofile")
+	if isoFile != "" && !slices.Contains(fakeISOFiles, isoFile) {
+		return "", nil, fakeBadRequest("ISO file %s does not exist", isoFile)
+	}
+	srv.ISOFile = isoFile
+	return "server", f.serverCopy(srv), nil
+}
+
+// fakeBackupTimes are the times of the latest backup image for each backup
+// schedule frequency.
+var fakeBackupTimes = map[string]string{
+	"daily":  "2026-10-17T02:00:00+02:00",
+	"weekly": "2026-10-12T02:00:00+02:00",
+}
+
+// fakeServerBackups returns a backup image for each backup schedule of the
+// server.
+func fakeServerBackups(srv *glesys.ServerDetails) []serverBackup {
+	var backups []serverBackup
+	for _, schedule := range srv.Backup.Schedules {
+		backups = append(backups, serverBackup{
+			ID:        srv.ID + "-" + schedule.Frequency,
+			CreatedAt: fakeBackupTimes[schedule.Frequency],
+		})
+	}
+	return backups
+}
+
+func (f *fakeGlesysAPI) serverListBackups(req *fakeRequest) (string, interface{}, *fakeError) {
+	srv, err := f.server(req.str("serverid"))
+	if err != nil {
+		return "", nil, err
+	}
+	if srv.Platform != "KVM" {
+		return "", nil, fakeBadRequest("Backups are only available for KVM servers")
+	}
+	return "backups", fakeServerBackups(srv), nil
+}
+
+func (f *fakeGlesysAPI) serverClone(req *fakeRequest) (string, interface{}, *fakeError) {
+	var params cloneServerParams
+	if err := req.decode(&params); err != nil {
+		return "", nil, err
+	}
+
+	source, err := f.server(params.ServerID)
+	if err != nil {
+		return "", nil, err
+	}
+	if params.BackupID != "" && !slices.ContainsFunc(fakeServerBackups(source), func(b serverBackup) bool { return b.ID == params.BackupID }) {
+		return "", nil, fakeNotFound("Backup %s of server %s does not exist", params.BackupID, source.ID)
+	}
+
+	prefix := "kvm"
+	if source.Platform == "VMware" {
+		prefix = "wps"
+	}
+
+	srv := &glesys.ServerDetails{
+		ID:              f.nextID(prefix),
+		Bandwidth:       params.Bandwidth,
+		CPU:             params.CPU,
+		DataCenter:      params.DataCenter,
+		Description:     params.Description,
+		Hostname:        params.Hostname,
+		InitialTemplate: source.InitialTemplate,
+		IsRunning:       true,
+		Memory:          params.Memory,
+		Platform:        source.Platform,
+		State:           "running",
+		Storage:         params.Storage,
+		Template:        source.Template,
+	}
+	if len(params.Backup) > 0 {
+		srv.Backup = glesys.ServerBackupDetails{Enabled: "yes", Schedules: params.Backup}
+	}
+
+	if err := f.addServer(srv, params.IPv4, params.IPv6); err != nil {
+		return "", nil, err
+	}
+	return "server", f.serverCopy(srv), nil
+}
+
+func (f *fakeGlesysAPI) serverStart(req *fakeRequest) (string, interface{}, *fakeError) {
+	srv, err := f.server(req.str("serverid"))
+	if err != nil {
+		return "", nil, err
+	}
+	srv.IsRunning = true
+	srv.State = "running"
+	return "", nil, nil
+}
+
+func (f *fakeGlesysAPI) serverStop(req *fakeRequest) (string, interface{}, *fakeError) {
+	srv, err := f.server(req.str("serverid"))
+	if err != nil {
+		return "", nil, err
+	}
+	if req.str("type") == "reboot" {
+		if !f.quickReboots {
+			f.reboots[srv.ID] = 0
+		}
+		return "", nil, nil
+	}
+	srv.IsRunning = false
+	srv.State = "stopped"
+	return "", nil, nil
+}
+
+// Server disks
+
+func (f *fakeGlesysAPI) serverDiskCreate(req *fakeRequest) (string, interface{}, *fakeError) {
+	var params glesys.CreateServerDiskParams
+	if err := req.decode(&params); err != nil {
+		return "", nil, err
+	}
+
+	srv, err := f.server(params.ServerID)
+	if err != nil {
+		return "", nil, err
+	}
+	if srv.IsLocked {
+		return "", nil, fakeBadRequest("Server %s is locked", srv.ID)
+	}
+
+	diskType := params.Type
+	if diskType == "" {
+		diskType = "gold"
+	}
+	disk := glesys.ServerDiskDetails{
+		ID:        f.nextID("disk-"),
+		Name:      params.Name,
+		SizeInGIB: params.SizeInGIB,
+		SCSIID:    len(srv.AdditionalDisks) + 1,
+		Type:      diskType,
+	}
+	srv.AdditionalDisks = append(srv.AdditionalDisks, disk)
+
+	return "disk", disk, nil
+}
+
+func (f *fakeGlesysAPI) findDisk(id string) (*glesys.ServerDetails, int, *fakeError) {
+	for _, srv := range f.servers {
+		for i := range srv.AdditionalDisks {
+			if srv.AdditionalDisks[i].ID == id {
+				return srv, i, nil
+			}
+		}
+	}
+	return nil, 0, fakeNotFound("Disk %s does not exist", id)
+}
+
+func (f *fakeGlesysAPI) serverDiskEdit(req *fakeRequest) (string, interface{}, *fakeError) {
+	var params glesys.EditServerDiskParams
+	if err := req.decode(&params); err != nil {
+		return "", nil, err
+	}
+
+	srv, i, err := f.findDisk(params.ID)
+	if err != nil {
+		return "", nil, err
+	}
+
+	disk := &srv.AdditionalDisks[i]
+	if params.Name != "" {
+		disk.Name = params.Name
+	}
+	if params.SizeInGIB != 0 {
+		if params.SizeInGIB < disk.SizeInGIB {
+			return "", nil, fakeBadRequest("Disk size can not be decreased")
+		}
+		disk.SizeInGIB = params.SizeInGIB
+	}
+
+	return "disk", *disk, nil
+}
+
+func (f *fakeGlesysAPI) serverDiskDelete(req *fakeRequest) (string, interface{}, *fakeError) {
+	srv, i, err := f.findDisk(req.str("id"))
+	if err != nil {
+		return "", nil, err
+	}
+	srv.AdditionalDisks = append(srv.AdditionalDisks[:i], srv.AdditionalDisks[i+1:]...)
+	return "", nil, nil
+}
+
+func (f *fakeGlesysAPI) serverDiskLimits(req *fakeRequest) (string, interface{}, *fakeError) {
+	srv, err := f.server(req.str("serverid"))
+	if err != nil {
+		return "", nil, err
+	}
+	return "limits", glesys.ServerDiskLimitsDetails{
+		MinSizeInGIB:    10,
+		MaxSizeInGIB:    1024,
+		MaxNumDisks:     3,
+		CurrentNumDisks: len(srv.AdditionalDisks),
+	}, nil
+}
+
+func (f *fakeGlesysAPI) serverDiskEstimatedCost(req *fakeRequest) (string, interface{}, *fakeError) {
+	var params serverDiskEstimatedCostParams
+	if err := req.decode(&params); err != nil {
+		return "", nil, err
+	}
+	if _, err := f.server(params.ServerID); err != nil {
+		return "", nil, err
+	}
+
+	price := 2.0
+	if params.Type == "gold" {
+		price = 4.0
+	}
+	return "billing", fakeBilling(0, float64(params.SizeInGIB)*price), nil
+}
+
+// fakeBilling returns an estimate going from current to estimated per month.
+func fakeBilling(current, estimated float64) glesys.Billing {
+	var billing glesys.Billing
+	billing.Currency = "SEK"
+	billing.Current.Price = current
+	billing.Current.Total = current
+	billing.Estimated.Price = estimated
+	billing.Estimated.Total = estimated
+	billing.Diff.Price = estimated - current
+	billing.Diff.Total = estimated - current
+	return billing
+}
+
+// fakeServerPrice is the monthly price of a server with the given resources.
+func fakeServerPrice(cpu, memory, storage int) float64 {
+	return float64(cpu)*100 + float64(memory)/1024*50 + float64(storage)*2
+}
+
+// Network adapters
+
+func (f *fakeGlesysAPI) networkAdapter(id string) (*glesys.NetworkAdapter, *fakeError) {
+	adapter, ok := f.networkAdapters[id]
+	if !ok {
+		return nil, fakeNotFound("Network adapter %s does not exist", id)
+	}
+	return adapter, nil
+}
+
+func (f *fakeGlesysAPI) networkAdapterCreate(req *fakeRequest) (string, interface{}, *fakeError) {
+	var params glesys.CreateNetworkAdapterParams
+	if err := req.decode(&params); err != nil {
+		return "", nil, err
+	}
+
+	srv, err := f.server(params.ServerID)
+	if err != nil {
+		return "", nil, err
+	}
+	if srv.IsLocked {
+		return "", nil, fakeBadRequest("Server %s is locked", srv.ID)
+	}
+
+	count := 1
+	for _, adapter := range f.networkAdapters {
+		if adapter.ServerID == srv.ID {
+			count++
+		}
+	}
+
+	adapter := &glesys.NetworkAdapter{
+		ID:          f.nextID("na"),
+		AdapterType: params.AdapterType,
+		Bandwidth:   params.Bandwidth,
+		Name:        params.Name,
+		NetworkID:   params.NetworkID,
+		ServerID:    srv.ID,
+		State:       "ready",
+	}
+	if adapter.AdapterType == "" {
+		adapter.AdapterType = "VMXNET 3"
+	}
+	if adapter.Bandwidth == 0 {
+		adapter.Bandwidth = 100
+	}
+	if adapter.Name == "" {
+		adapter.Name = fmt.Sprintf("Network adapter %d", count)
+	}
+	if adapter.NetworkID == "" {
+		adapter.NetworkID = "internet-" + strings.ToLower(srv.DataCenter)
+	}
+	f.networkAdapters[adapter.ID] = adapter
+
+	return "networkadapter", *adapter, nil
+}
+
+func (f *fakeGlesysAPI) networkAdapterDetails(req *fakeRequest) (string, interface{}, *fakeError) {
+	adapter, err := f.networkAdapter(req.str("networkadapterid"))
+	if err != nil {
+		return "", nil, err
+	}
+	return "networkadapter", *adapter, nil
+}
+
+func (f *fakeGlesysAPI) networkAdapterEdit(req *fakeRequest) (string, interface{}, *fakeError) {
+	var params struct {
+		glesys.EditNetworkAdapterParams
+		NetworkAdapterID string `json:"networkadapterid"`
+	}
+	if err := req.decode(&params); err != nil {
+		return "", nil, err
+	}
+
+	adapter, err := f.networkAdapter(params.NetworkAdapterID)
+	if err != nil {
+		return "", nil, err
+	}
+	if params.Bandwidth != 0 {
+		adapter.Bandwidth = params.Bandwidth
+	}
+	if params.Name != "" {
+		adapter.Name = params.Name
+	}
+	if params.NetworkID != "" {
+		adapter.NetworkID = params.NetworkID
+	}
+	return "networkadapter", *adapter, nil
+}
+
+func (f *fakeGlesysAPI) networkAdapterDelete(req *fakeRequest) (string, interface{}, *fakeError) {
+	adapter, err := f.networkAdapter(req.str("networkadapterid"))
+	if err != nil {
+		return "", nil, err
+	}
+	delete(f.networkAdapters, adapter.ID)
+	return "", nil, nil
+}
+
+// Networks
+
+func (f *fakeGlesysAPI) network(id string) (*glesys.Network, *fakeError) {
+	network, ok := f.networks[id]
+	if !ok {
+		return nil, fakeNotFound("Network %s does not exist", id)
+	}
+	return network, nil
+}
+
+func (f *fakeGlesysAPI) networkCreate(req *fakeRequest) (string, interface{}, *fakeError) {
+	var params glesys.CreateNetworkParams
+	if err := req.decode(&params); err != nil {
+		return "", nil, err
+	}
+
+	network := &glesys.Network{
+		ID:          f.nextID("vl"),
+		DataCenter:  params.DataCenter,
+		Description: params.Description,
+		Public:      "no",
+	}
+	f.networks[network.ID] = network
+
+	return "network", *network, nil
+}
+
+func (f *fakeGlesysAPI) networkDetails(req *fakeRequest) (string, interface{}, *fakeError) {
+	network, err := f.network(req.str("networkid"))
+	if err != nil {
+		return "", nil, err
+	}
+	return "network", *network, nil
+}
+
+func (f *fakeGlesysAPI) networkEdit(req *fakeRequest) (string, interface{}, *fakeError) {
+	var params struct {
+		glesys.EditNetworkParams
+		NetworkID string `json:"networkid"`
+	}
+	if err := req.decode(&params); err != nil {
+		return "", nil, err
+	}
+
+	network, err := f.network(params.NetworkID)
+	if err != nil {
+		return "", nil, err
+	}
+	if params.Description != "" {
+		network.Description = params.Description
+	}
+	return "network", *network, nil
+}
+
+func (f *fakeGlesysAPI) networkDelete(req *fakeRequest) (string, interface{}, *fakeError) {
+	network, err := f.network(req.str("networkid"))
+	if err != nil {
+		return "", nil, err
+	}
+	delete(f.networks, network.ID)
+	return "", nil, nil
+}
+
+func (f *fakeGlesysAPI) networkList(req *fakeRequest) (string, interface{}, *fakeError) {
+	networks := []glesys.Network{}
+	for _, network := range f.networks {
+		networks = append(networks, *network)
+	}
+	return "networks", networks, nil
+}
+
+// IPs
+
+// newIP adds a previously unknown, unreserved address to the pool.
+func (f *fakeGlesysAPI) newIP(datacenter, platform string, version int) *glesys.IP {
+	f.id++
+	ip := &glesys.IP{
+		Cost:            glesys.IPCost{Amount: 20, Currency: "SEK", TimePeriod: "month"},
+		DataCenter:      datacenter,
+		LockedToAccount: "no",
+		NameServers:     []string{"79.99.4.100", "79.99.4.101"},
+		Platform:        platform,
+		Platforms:       []string{platform},
+		Reserved:        "no",
+		Version:         version,
+	}
+	if version == 6 {
+		ip.Address = fmt.Sprintf("2001:db8::%x", f.id)
+		ip.Netmask = "/64"
+		ip.Broadcast = "2001:db8::ffff"
+		ip.Gateway = "2001:db8::1"
+		ip.PTR = fmt.Sprintf("%s.static.glesys.net.", strings.ReplaceAll(ip.Address, ":", "-"))
+	} else {
+		ip.Address = fmt.Sprintf("203.0.%d.%d", 113+f.id/250, 2+f.id%250)
+		ip.Netmask = "255.255.255.0"
+		ip.Broadcast = fmt.Sprintf("203.0.%d.255", 113+f.id/250)
+		ip.Gateway = fmt.Sprintf("203.0.%d.1", 113+f.id/250)
+		ip.PTR = defaultFakePTR(ip.Address)
+	}
+	f.ips[ip.Address] = ip
+	return ip
+}
+
+func defaultFakePTR(address string) string {
+	return strings.ReplaceAll(address, ".", "-") + "-static.glesys.net."
+}
+
+func (f *fakeGlesysAPI) ip(address string) (*glesys.IP, *fakeError) {
+	ip, ok := f.ips[address]
+	if !ok {
+		return nil, fakeNotFound("IP %s does not exist", address)
+	}
+	return ip, nil
+}
+
+func (f *fakeGlesysAPI) ipListFree(req *fakeRequest) (string, interface{}, *fakeError) {
+	var params glesys.AvailableIPsParams
+	if err := req.decode(&params); err != nil {
+		return "", nil, err
+	}
+
+	addresses := []string{}
+	for i := 0; i < 3; i++ {
+		addresses = append(addresses, f.newIP(params.DataCenter, params.Platform, params.Version).Address)
+	}
+	return "iplist", map[string]interface{}{"ipaddresses": addresses}, nil
+}
+
+func (f *fakeGlesysAPI) ipListOwn(req *fakeRequest) (string, interface{}, *fakeError) {
+	var params glesys.ReservedIPsParams
+	if err := req.decode(&params); err != nil {
+		return "", nil, err
+	}
+
+	ips := []glesys.IP{}
+	for _, ip := range f.ips {
+		if ip.Reserved != "yes" {
+			continue
+		}
+		if params.DataCenter != "" && ip.DataCenter != params.DataCenter {
+			continue
+		}
+		if params.Platform != "" && ip.Platform != params.Platform {
+			continue
+		}
+		if params.Version != 0 && ip.Version != params.Version {
+			continue
+		}
+		ips = append(ips, *ip)
+	}
+	return "iplist", ips, nil
+}
+
+func (f *fakeGlesysAPI) ipTake(req *fakeRequest) (string, interface{}, *fakeError) {
+	ip, err := f.ip(req.str("ipaddress"))
+	if err != nil {
+		return "", nil, err
+	}
+	if ip.Reserved == "yes" {
+		return "", nil, fakeBadRequest("IP %s is already reserved", ip.Address)
+	}
+	ip.Reserved = "yes"
+	return "details", *ip, nil
+}
+
+func (f *fakeGlesysAPI) ipRelease(req *fakeRequest) (string, interface{}, *fakeError) {
+	ip, err := f.ip(req.str("ipaddress"))
+	if err != nil {
+		return "", nil, err
+	}
+	if ip.Reserved != "yes" {
+		return "", nil, fakeNotFound("IP %s is not reserved by this project", ip.Address)
+	}
+	ip.Reserved = "no"
+	ip.ServerID = ""
+	ip.PTR = defaultFakePTR(ip.Address)
+	return "", nil, nil
+}
+
+func (f *fakeGlesysAPI) ipAdd(req *fakeRequest) (string, interface{}, *fakeError) {
+	srv, err := f.server(req.str("serverid"))
+	if err != nil {
+		return "", nil, err
+	}
+	if srv.IsLocked {
+		return "", nil, fakeBadRequest("Server %s is locked", srv.ID)
+	}
+	if err := f.assignServerIP(srv, 0, req.str("ipaddress")); err != nil {
+		return "", nil, err
+	}
+	return "", nil, nil
+}
+
+func (f *fakeGlesysAPI) ipRemove(req *fakeRequest) (string, interface{}, *fakeError) {
+	ip, err := f.ip(req.str("ipaddress"))
+	if err != nil {
+		return "", nil, err
+	}
+	if ip.ServerID == "" {
+		return "", nil, fakeBadRequest("IP %s is not used by a server", ip.Address)
+	}
+	ip.ServerID = ""
+	if req.str("release") == "true" {
+		ip.Reserved = "no"
+		ip.PTR = defaultFakePTR(ip.Address)
+	}
+	return "", nil, nil
+}
+
+func (f *fakeGlesysAPI) ipDetails(req *fakeRequest) (string, interface{}, *fakeError) {
+	ip, err := f.ip(req.str("ipaddress"))
+	if err != nil {
+		return "", nil, err
+	}
+	return "details", *ip, nil
+}
+
+func (f *fakeGlesysAPI) ipSetPTR(req *fakeRequest) (string, interface{}, *fakeError) {
+	ip, err := f.ip(req.str("ipaddress"))
+	if err != nil {
+		return "", nil, err
+	}
+	ip.PTR = req.str("data")
+	return "details", *ip, nil
+}
+
+func (f *fakeGlesysAPI) ipResetPTR(req *fakeRequest) (string, interface{}, *fakeError) {
+	ip, err := f.ip(req.str("ipaddress"))
+	if err != nil {
+		return "", nil, err
+	}
+	ip.PTR = defaultFakePTR(ip.Address)
+	return "details", *ip, nil
+}
+
+// DNS domains
+
+func (f *fakeGlesysAPI) domain(name string) (*glesys.DNSDomain, *fakeError) {
+	domain, ok := f.domains[name]
+	if !ok {
+		return nil, fakeNotFound("Domain %s does not exist", name)
+	}
+	return domain, nil
+}
+
+func (f *fakeGlesysAPI) domainAdd(req *fakeRequest) (string, interface{}, *fakeError) {
+	var params glesys.AddDNSDomainParams
+	if err := req.decode(&params); err != nil {
+		return "", nil, err
+	}
+	if _, ok := f.domains[params.Name]; ok {
+		return "", nil, fakeBadRequest("Domain %s already exists", params.Name)
+	}
+
+	domain := &glesys.DNSDomain{
+		Name:                  params.Name,
+		CreateTime:            "2026-01-01T00:00:00+01:00",
+		DisplayName:           params.Name,
+		Expire:                1814400,
+		Minimum:               10800,
+		PrimaryNameServer:     "ns1.namesystem.se.",
+		Refresh:               10800,
+		RegistrarInfo:         glesys.RegistrarInfo{AutoRenew: "no", State: "NOTREGISTERED"},
+		ResponsiblePerson:     "registry.glesys.se.",
+		Retry:                 2700,
+		TTL:                   3600,
+		UsingGlesysNameserver: "yes",
+	}
+	fakeApplyDomainParams(domain, glesys.EditDNSDomainParams{
+		Expire:            params.Expire,
+		Minimum:           params.Minimum,
+		PrimaryNameServer: params.PrimaryNameServer,
+		Refresh:           params.Refresh,
+		ResponsiblePerson: params.ResponsiblePerson,
+		Retry:             params.Retry,
+		TTL:               params.TTL,
+	})
+	f.domains[domain.Name] = domain
+
+	return "domain", *domain, nil
+}
+
+func fakeApplyDomainParams(domain *glesys.DNSDomain, params glesys.EditDNSDomainParams) {
+	if params.Expire != 0 {
+		domain.Expire = params.Expire
+	}
+	if params.Minimum != 0 {
+		domain.Minimum = params.Minimum
+	}
+	if params.PrimaryNameServer != "" {
+		domain.PrimaryNameServer = params.PrimaryNameServer
+	}
+	if params.Refresh != 0 {
+		domain.Refresh = params.Refresh
+	}
+	if params.ResponsiblePerson != "" {
+		domain.ResponsiblePerson = params.ResponsiblePerson
+	}
+	if params.Retry != 0 {
+		domain.Retry = params.Retry
+	}
+	if params.TTL != 0 {
+		domain.TTL = params.TTL
+	}
+}
+
+func (f *fakeGlesysAPI) domainDetails(req *fakeRequest) (string, interface{}, *fakeError) {
+	domain, err := f.domain(req.str("domainname"))
+	if err != nil {
+		return "", nil, err
+	}
+
+	out := *domain
+	for _, record := range f.records {
+		if record.DomainName == domain.Name {
+			out.RecordCount++
+		}
+	}
+	return "domain", out, nil
+}
+
+func (f *fakeGlesysAPI) domainEdit(req *fakeRequest) (string, interface{}, *fakeError) {
+	var params glesys.EditDNSDomainParams
+	if err := req.decode(&params); err != nil {
+		return "", nil, err
+	}
+
+	domain, err := f.domain(params.Name)
+	if err != nil {
+		return "", nil, err
+	}
+	fakeApplyDomainParams(domain, params)
+	return "domain", *domain, nil
+}
+
+func (f *fakeGlesysAPI) domainDelete(req *fakeRequest) (string, interface{}, *fakeError) {
+	domain, err := f.domain(req.str("domainname"))
+	if err != nil {
+		return "", nil, err
+	}
+	for id, record := range f.records {
+		if record.DomainName == domain.Name {
+			delete(f.records, id)
+		}
+	}
+	delete(f.domains, domain.Name)
+	return "", nil, nil
+}
+
+func (f *fakeGlesysAPI) domainList(req *fakeRequest) (string, interface{}, *fakeError) {
+	domains := []glesys.DNSDomain{}
+	for _, domain := range f.domains {
+		domains = append(domains, *domain)
+	}
+	return "domains", domains, nil
+}
+
+func (f *fakeGlesysAPI) domainListRecords(req *fakeRequest) (string, interface{}, *fakeError) {
+	domain, err := f.domain(req.str("domainname"))
+	if err != nil {
+		return "", nil, err
+	}
+
+	records := []glesys.DNSDomainRecord{}
+	for _, record := range f.records {
+		if record.DomainName == domain.Name {
+			records = append(records, *record)
+		}
+	}
+	return "records", records, nil
+}
+
+func (f *fakeGlesysAPI) domainAddRecord(req *fakeRequest) (string, interface{}, *fakeError) {
+	var params glesys.AddRecordParams
+	if err := req.decode(&params); err != nil {
+		return "", nil, err
+	}
+	if _, err := f.domain(params.DomainName); err != nil {
+		return "", nil, err
+	}
+
+	f.id++
+	record := &glesys.DNSDomainRecord{
+		DomainName: params.DomainName,
+		Data:       params.Data,
+		Host:       params.Host,
+		RecordID:   f.id,
+		TTL:        params.TTL,
+		Type:       params.Type,
+	}
+	if record.TTL == 0 {
+		record.TTL = 3600
+	}
+	f.records[record.RecordID] = record
+
+	return "record", *record, nil
+}
+
+func (f *fakeGlesysAPI) domainUpdateRecord(req *fakeRequest) (string, interface{}, *fakeError) {
+	var params glesys.UpdateRecordParams
+	if err := req.decode(&params); err != nil {
+		return "", nil, err
+	}
+
+	record, ok := f.records[params.RecordID]
+	if !ok {
+		return "", nil, fakeNotFound("Record %d does not exist", params.RecordID)
+	}
+	if params.Data != "" {
+		record.Data = params.Data
+	}
+	if params.Host != "" {
+		record.Host = params.Host
+	}
+	if params.TTL != 0 {
+		record.TTL = params.TTL
+	}
+	if params.Type != "" {
+		record.Type = params.Type
+	}
+	return "record", *record, nil
+}
+
+func (f *fakeGlesysAPI) domainDeleteRecord(req *fakeRequest) (string, interface{}, *fakeError) {
+	id, _ := strconv.Atoi(req.str("recordid"))
+	if _, ok := f.records[id]; !ok {
+		return "", nil, fakeNotFound("Record %d does not exist", id)
+	}
+	delete(f.records, id)
+	return "", nil, nil
+}
+
+// Email
+
+func fakeEmailDomain(address string) string {
+	if i := strings.LastIndex(address, "@"); i >= 0 {
+		return address[i+1:]
+	}
+	return ""
+}
+
+func (f *fakeGlesysAPI) emailCreateAccount(req *fakeRequest) (string, interface{}, *fakeError) {
+	var params glesys.CreateAccountParams
+	if err := req.decode(&params); err != nil {
+		return "", nil, err
+	}
+	if _, err := f.domain(fakeEmailDomain(params.EmailAccount)); err != nil {
+		return "", nil, err
+	}
+	if _, ok := f.emailAccounts[params.EmailAccount]; ok {
+		return "", nil, fakeBadRequest("Email account %s already exists", params.EmailAccount)
+	}
+
+	account := &glesys.EmailAccount{
+		EmailAccount:         params.EmailAccount,
+		DisplayName:          params.EmailAccount,
+		QuotaInGiB:           1,
+		AntiSpamLevel:        3,
+		AntiVirus:            "yes",
+		AutoRespond:          "no",
+		AutoRespondSaveEmail: "yes",
+		RejectSpam:           "no",
+		Created:              "2026-01-01T00:00:00+01:00",
+	}
+	fakeApplyAccountParams(account, glesys.EditAccountParams{
+		AntiSpamLevel:      params.AntiSpamLevel,
+		AntiVirus:          params.AntiVirus,
+		AutoRespond:        params.AutoRespond,
+		AutoRespondMessage: params.AutoRespondMessage,
+		QuotaInGiB:         params.QuotaInGiB,
+		RejectSpam:         params.RejectSpam,
+	})
+	f.emailAccounts[account.EmailAccount] = account
+
+	out := *account
+	out.Password = "fake-generated-password"
+	return "emailaccount", out, nil
+}
+
+func fakeApplyAccountParams(account *glesys.EmailAccount, params glesys.EditAccountParams) {
+	if params.AntiSpamLevel != 0 {
+		account.AntiSpamLevel = params.AntiSpamLevel
+	}
+	if params.AntiVirus != "" {
+		account.AntiVirus = params.AntiVirus
+	}
+	if params.AutoRespond != "" {
+		account.AutoRespond = params.AutoRespond
+	}
+	if params.AutoRespondMessage != "" {
+		account.AutoRespondMessage = params.AutoRespondMessage
+	}
+	if params.QuotaInGiB != 0 {
+		account.QuotaInGiB = params.QuotaInGiB
+	}
+	if params.RejectSpam != "" {
+		account.RejectSpam = params.RejectSpam
+	}
+}
+
+func (f *fakeGlesysAPI) emailEditAccount(req *fakeRequest) (string, interface{}, *fakeError) {
+	var params struct {
+		glesys.EditAccountParams
+		EmailAccount string `json:"emailaccount"`
+	}
+	if err := req.decode(&params); err != nil {
+		return "", nil, err
+	}
+
+	account, ok := f.emailAccounts[params.EmailAccount]
+	if !ok {
+		return "", nil, fakeNotFound("Email account %s does not exist", params.EmailAccount)
+	}
+	fakeApplyAccountParams(account, params.EditAccountParams)
+	account.Modified = "2026-01-02T00:00:00+01:00"
+	return "emailaccount", *account, nil
+}
+
+func (f *fakeGlesysAPI) emailCreateAlias(req *fakeRequest) (string, interface{}, *fakeError) {
+	var params glesys.EmailAliasParams
+	if err := req.decode(&params); err != nil {
+		return "", nil, err
+	}
+	if _, err := f.domain(fakeEmailDomain(params.EmailAlias)); err != nil {
+		return "", nil, err
+	}
+	if _, ok := f.emailAliases[params.EmailAlias]; ok {
+		return "", nil, fakeBadRequest("Email alias %s already exists", params.EmailAlias)
+	}
+
+	alias := &glesys.EmailAlias{
+		EmailAlias:  params.EmailAlias,
+		DisplayName: params.EmailAlias,
+		GoTo:        params.GoTo,
+	}
+	f.emailAliases[alias.EmailAlias] = alias
+	return "alias", *alias, nil
+}
+
+func (f *fakeGlesysAPI) emailEditAlias(req *fakeRequest) (string, interface{}, *fakeError) {
+	var params glesys.EmailAliasParams
+	if err := req.decode(&params); err != nil {
+		return "", nil, err
+	}
+
+	alias, ok := f.emailAliases[params.EmailAlias]
+	if !ok {
+		return "", nil, fakeNotFound("Email alias %s does not exist", params.EmailAlias)
+	}
+	alias.GoTo = params.GoTo
+	return "alias", *alias, nil
+}
+
+func (f *fakeGlesysAPI) emailList(req *fakeRequest) (string, interface{}, *fakeError) {
+	domain := req.str("domainname")
+	filter := req.str("filter")
+	if _, err := f.domain(domain); err != nil {
+		return "", nil, err
+	}
+
+	list := glesys.EmailList{
+		EmailAccounts: []glesys.EmailAccount{},
+		EmailAliases:  []glesys.EmailAlias{},
+	}
+	for _, account := range f.emailAccounts {
+		if fakeEmailDomain(account.EmailAccount) == domain && strings.Contains(account.EmailAccount, filter) {
+			list.EmailAccounts = append(list.EmailAccounts, *account)
+		}
+	}
+	for _, alias := range f.emailAliases {
+		if fakeEmailDomain(alias.EmailAlias) == domain && strings.Contains(alias.EmailAlias, filter) {
+			list.EmailAliases = append(list.EmailAliases, *alias)
+		}
+	}
+	return "list", list, nil
+}
+
+func (f *fakeGlesysAPI) emailDelete(req *fakeRequest) (string, interface{}, *fakeError) {
+	email := req.str("email")
+	if _, ok := f.emailAccounts[email]; ok {
+		delete(f.emailAccounts, email)
+		return "", nil, nil
+	}
+	if _, ok := f.emailAliases[email]; ok {
+		delete(f.emailAliases, email)
+		return "", nil, nil
+	}
+	return "", nil, fakeNotFound("Email %s does not exist", email)
+}
+
+// Databases
+
+var fakeDatabasePlans = []glesys.DatabasePlan{
+	{Key: "plan-1core-4gib-25gib", CpuCores: 1, MemoryInGib: 4, StorageInGib: 25},
+	{Key: "plan-2core-8gib-50gib", CpuCores: 2, MemoryInGib: 8, StorageInGib: 50},
+}
+
+func (f *fakeGlesysAPI) database(id string) (*glesys.DatabaseDetails, *fakeError) {
+	database, ok := f.databases[id]
+	if !ok {
+		return nil, fakeNotFound("Database %s does not exist", id)
+	}
+	return database, nil
+}
+
+func (f *fakeGlesysAPI) databaseCreate(req *fakeRequest) (string, interface{}, *fakeError) {
+	var params glesys.CreateDatabaseParams
+	if err := req.decode(&params); err != nil {
+		return "", nil, err
+	}
+
+	var plan *glesys.DatabasePlan
+	for i := range fakeDatabasePlans {
+		if fakeDatabasePlans[i].Key == params.PlanKey {
+			plan = &fakeDatabasePlans[i]
+		}
+	}
+	if plan == nil {
+		return "", nil, fakeBadRequest("Plan %s does not exist", params.PlanKey)
+	}
+
+	database := &glesys.DatabaseDetails{
+		DataCenterKey: params.DataCenterKey,
+		ID:            f.nextID("db-"),
+		Name:          params.Name,
+		Engine:        strings.ToUpper(params.Engine),
+		EngineVersion: params.EngineVersion,
+		Status:        "RUNNING",
+		Allowlist:     params.AllowList,
+		Plan:          *plan,
+	}
+	database.Fqdn = database.ID + ".db.example.test"
+	database.MaintenanceWindow.WeekDay = "sunday"
+	database.MaintenanceWindow.StartTime = "03:00"
+	database.MaintenanceWindow.DurationInMinutes = 60
+	f.databases[database.ID] = database
+
+	return "database", *database, nil
+}
+
+func (f *fakeGlesysAPI) databaseEstimatedCost(req *fakeRequest) (string, interface{}, *fakeError) {
+	var params glesys.EstimatedCostParams
+	if err := req.decode(&params); err != nil {
+		return "", nil, err
+	}
+
+	for _, plan := range fakeDatabasePlans {
+		if plan.Key == params.PlanKey {
+			return "billing", fakeBilling(0, fakeServerPrice(plan.CpuCores, plan.MemoryInGib*1024, plan.StorageInGib)), nil
+		}
+	}
+	return "", nil, fakeBadRequest("Plan %s does not exist", params.PlanKey)
+}
+
+func (f *fakeGlesysAPI) databaseDetails(req *fakeRequest) (string, interface{}, *fakeError) {
+	database, err := f.database(req.str("id"))
+	if err != nil {
+		return "", nil, err
+	}
+	return "database", *database, nil
+}
+
+func (f *fakeGlesysAPI) databaseConnectionDetails(req *fakeRequest) (string, interface{}, *fakeError) {
+	database, err := f.database(req.str("id"))
+	if err != nil {
+		return "", nil, err
+	}
+	return "connectiondetails", glesys.ConnectionDetails{
+		ConnectionString: fmt.Sprintf("%s://admin:secret@%s:3306", strings.ToLower(database.Engine), database.Fqdn),
+	}, nil
+}
+
+func (f *fakeGlesysAPI) databaseUpdateAllowlist(req *fakeRequest) (string, interface{}, *fakeError) {
+	var params glesys.UpdateAllowlistParams
+	if err := req.decode(&params); err != nil {
+		return "", nil, err
+	}
+
+	database, err := f.database(params.ID)
+	if err != nil {
+		return "", nil, err
+	}
+	database.Allowlist = params.AllowList
+	return "database", *database, nil
+}
+
+func (f *fakeGlesysAPI) databaseDelete(req *fakeRequest) (string, interface{}, *fakeError) {
+	database, err := f.database(req.str("id"))
+	if err != nil {
+		return "", nil, err
+	}
+	delete(f.databases, database.ID)
+	return "", nil, nil
+}
+
+func (f *fakeGlesysAPI) databaseList(req *fakeRequest) (string, interface{}, *fakeError) {
+	databases := []glesys.Database{}
+	for _, database := range f.databases {
+		databases = append(databases, glesys.Database{
+			DataCenterKey: database.DataCenterKey,
+			ID:            database.ID,
+			Name:          database.Name,
+			Engine:        database.Engine,
+			EngineVersion: database.EngineVersion,
+		})
+	}
+	return "databases", databases, nil
+}
+
+func (f *fakeGlesysAPI) databaseListPlans(req *fakeRequest) (string, interface{}, *fakeError) {
+	return "plans", fakeDatabasePlans, nil
+}
+
+// Load balancers
+
+func (f *fakeGlesysAPI) loadBalancer(id string) (*glesys.LoadBalancerDetails, *fakeError) {
+	lb, ok := f.loadBalancers[id]
+	if !ok {
+		return nil, fakeNotFound("Load balancer %s does not exist", id)
+	}
+	return lb, nil
+}
+
+// loadBalancerCopy returns a deep copy of the load balancer, so that later
+// changes to the state don't leak into responses.
+func loadBalancerCopy(lb *glesys.LoadBalancerDetails) glesys.LoadBalancerDetails {
+	out := *lb
+	out.Blocklists = append([]string{}, lb.Blocklists...)
+	out.FrontendsList = append([]glesys.LoadBalancerFrontend{}, lb.FrontendsList...)
+	out.BackendsList = nil
+	for _, backend := range lb.BackendsList {
+		backend.Targets = append([]glesys.Target{}, backend.Targets...)
+		out.BackendsList = append(out.BackendsList, backend)
+	}
+	return out
+}
+
+func (f *fakeGlesysAPI) loadBalancerCreate(req *fakeRequest) (string, interface{}, *fakeError) {
+	var params glesys.CreateLoadBalancerParams
+	if err := req.decode(&params); err != nil {
+		return "", nil, err
+	}
+
+	lb := &glesys.LoadBalancerDetails{
+		ID:         f.nextID("lb"),
+		DataCenter: params.DataCenter,
+		Name:       params.Name,
+		Cost:       glesys.LoadBalancerCost{Amount: 150, Currency: "SEK", Timeperiod: "month"},
+	}
+	ip := f.newIP(params.DataCenter, "KVM", 4)
+	ip.Reserved = "yes"
+	lb.IPList = []glesys.LoadBalancerIP{{Address: ip.Address, Version: 4}}
+	f.loadBalancers[lb.ID] = lb
+
+	return "loadbalancer", loadBalancerCopy(lb), nil
+}
+
+func (f *fakeGlesysAPI) loadBalancerDetails(req *fakeRequest) (string, interface{}, *fakeError) {
+	lb, err := f.loadBalancer(req.str("loadbalancerid"))
+	if err != nil {
+		return "", nil, err
+	}
+	return "loadbalancer", loadBalancerCopy(lb), nil
+}
+
+func (f *fakeGlesysAPI) loadBalancerEdit(req *fakeRequest) (string, interface{}, *fakeError) {
+	lb, err := f.loadBalancer(req.str("loadbalancerid"))
+	if err != nil {
+		return "", nil, err
+	}
+	if name := req.str("name"); name != "" {
+		lb.Name = name
+	}
+	return "loadbalancer", loadBalancerCopy(lb), nil
+}
+
+func (f *fakeGlesysAPI) loadBalancerDestroy(req *fakeRequest) (string, interface{}, *fakeError) {
+	lb, err := f.loadBalancer(req.str("loadbalancerid"))
+	if err != nil {
+		return "", nil, err
+	}
+	for _, ip := range lb.IPList {
+		if stored, ok := f.ips[ip.Address]; ok {
+			stored.Reserved = "no"
+		}
+	}
+	delete(f.loadBalancers, lb.ID)
+	return "", nil, nil
+}
+
+func (f *fakeGlesysAPI) loadBalancerList(req *fakeRequest) (string, interface{}, *fakeError) {
+	lbs := []glesys.LoadBalancer{}
+	for _, lb := range f.loadBalancers {
+		lbs = append(lbs, glesys.LoadBalancer{DataCenter: lb.DataCenter, ID: lb.ID, Name: lb.Name})
+	}
+	return "loadbalancers", lbs, nil
+}
+
+func (f *fakeGlesysAPI) backend(lb *glesys.LoadBalancerDetails, name string) (*glesys.LoadBalancerBackend, *fakeError) {
+	for i := range lb.BackendsList {
+		if lb.BackendsList[i].Name == name {
+			return &lb.BackendsList[i], nil
+		}
+	}
+	return nil, fakeNotFound("Backend %s does not exist", name)
+}
+
+func (f *fakeGlesysAPI) frontend(lb *glesys.LoadBalancerDetails, name string) (*glesys.LoadBalancerFrontend, *fakeError) {
+	for i := range lb.FrontendsList {
+		if lb.FrontendsList[i].Name == name {
+			return &lb.FrontendsList[i], nil
+		}
+	}
+	return nil, fakeNotFound("Frontend %s does not exist", name)
+}
+
+func (f *fakeGlesysAPI) target(backend *glesys.LoadBalancerBackend, name string) (*glesys.Target, *fakeError) {
+	for i := range backend.Targets {
+		if backend.Targets[i].Name == name {
+			return &backend.Targets[i], nil
+		}
+	}
+	return nil, fakeNotFound("Target %s does not exist", name)
+}
+
+func (f *fakeGlesysAPI) loadBalancerAddBackend(req *fakeRequest) (string, interface{}, *fakeError) {
+	var params struct {
+		glesys.AddBackendParams
+		LoadBalancerID string `json:"loadbalancerid"`
+	}
+	if err := req.decode(&params); err != nil {
+		return "", nil, err
+	}
+
+	lb, err := f.loadBalancer(params.LoadBalancerID)
+	if err != nil {
+		return "", nil, err
+	}
+	if _, err := f.backend(lb, params.Name); err == nil {
+		return "", nil, fakeBadRequest("Backend %s already exists", params.Name)
+	}
+
+	backend := glesys.LoadBalancerBackend{
+		ConnectTimeout:  params.ConnectTimeout,
+		Mode:            params.Mode,
+		Name:            params.Name,
+		ResponseTimeout: params.ResponseTimeout,
+		Status:          "DOWN",
+		StickySession:   params.StickySession,
+		Targets:         []glesys.Target{},
+	}
+	if backend.ConnectTimeout == 0 {
+		backend.ConnectTimeout = 4000
+	}
+	if backend.ResponseTimeout == 0 {
+		backend.ResponseTimeout = 50000
+	}
+	if backend.Mode == "" {
+		backend.Mode = "tcp"
+	}
+	if backend.StickySession == "" {
+		backend.StickySession = "no"
+	}
+	lb.BackendsList = append(lb.BackendsList, backend)
+
+	return "loadbalancer", loadBalancerCopy(lb), nil
+}
+
+func (f *fakeGlesysAPI) loadBalancerEditBackend(req *fakeRequest) (string, interface{}, *fakeError) {
+	var params struct {
+		glesys.EditBackendParams
+		LoadBalancerID string `json:"loadbalancerid"`
+	}
+	if err := req.decode(&params); err != nil {
+		return "", nil, err
+	}
+
+	lb, err := f.loadBalancer(params.LoadBalancerID)
+	if err != nil {
+		return "", nil, err
+	}
+	backend, err := f.backend(lb, params.Name)
+	if err != nil {
+		return "", nil, err
+	}
+	if params.ConnectTimeout != 0 {
+		backend.ConnectTimeout = params.ConnectTimeout
+	}
+	if params.Mode != "" {
+		backend.Mode = params.Mode
+	}
+	if params.ResponseTimeout != 0 {
+		backend.ResponseTimeout = params.ResponseTimeout
+	}
+	if params.StickySession != "" {
+		backend.StickySession = params.StickySession
+	}
+	return "loadbalancer", loadBalancerCopy(lb), nil
+}
+
+func (f *fakeGlesysAPI) loadBalancerRemoveBackend(req *fakeRequest) (string, interface{}, *fakeError) {
+	lb, err := f.loadBalancer(req.str("loadbalancerid"))
+	if err != nil {
+		return "", nil, err
+	}
+	name := req.str("backendname")
+	for i := range lb.BackendsList {
+		if lb.BackendsList[i].Name == name {
+			lb.BackendsList = append(lb.BackendsList[:i], lb.BackendsList[i+1:]...)
+			return "", nil, nil
+		}
+	}
+	return "", nil, fakeNotFound("Backend %s does not exist", name)
+}
+
+func (f *fakeGlesysAPI) loadBalancerAddFrontend(req *fakeRequest) (string, interface{}, *fakeError) {
+	var params struct {
+		glesys.AddFrontendParams
+		LoadBalancerID string `json:"loadbalancerid"`
+	}
+	if err := req.decode(&params); err != nil {
+		return "", nil, err
+	}
+
+	lb, err := f.loadBalancer(params.LoadBalancerID)
+	if err != nil {
+		return "", nil, err
+	}
+	if _, err := f.backend(lb, params.Backend); err != nil {
+		return "", nil, err
+	}
+	if _, err := f.frontend(lb, params.Name); err == nil {
+		return "", nil, fakeBadRequest("Frontend %s already exists", params.Name)
+	}
+
+	frontend := glesys.LoadBalancerFrontend{
+		Backend:        params.Backend,
+		ClientTimeout:  params.ClientTimeout,
+		MaxConnections: params.MaxConnections,
+		Name:           params.Name,
+		Port:           params.Port,
+		Status:         "OPEN",
+		SSLCertificate: params.SSLCertificate,
+	}
+	if frontend.ClientTimeout == 0 {
+		frontend.ClientTimeout = 50000
+	}
+	if frontend.MaxConnections == 0 {
+		frontend.MaxConnections = 2000
+	}
+	lb.FrontendsList = append(lb.FrontendsList, frontend)
+
+	return "loadbalancer", loadBalancerCopy(lb), nil
+}
+
+func (f *fakeGlesysAPI) loadBalancerEditFrontend(req *fakeRequest) (string, interface{}, *fakeError) {
+	var params struct {
+		glesys.EditFrontendParams
+		LoadBalancerID string `json:"loadbalancerid"`
+	}
+	if err := req.decode(&params); err != nil {
+		return "", nil, err
+	}
+
+	lb, err := f.loadBalancer(params.LoadBalancerID)
+	if err != nil {
+		return "", nil, err
+	}
+	frontend, err := f.frontend(lb, params.Name)
+	if err != nil {
+		return "", nil, err
+	}
+	if params.ClientTimeout != 0 {
+		frontend.ClientTimeout = params.ClientTimeout
+	}
+	if params.MaxConnections != 0 {
+		frontend.MaxConnections = params.MaxConnections
+	}
+	if params.Port != 0 {
+		frontend.Port = params.Port
+	}
+	if params.SSLCertificate != "" {
+		frontend.SSLCertificate = params.SSLCertificate
+	}
+	return "loadbalancer", loadBalancerCopy(lb), nil
+}
+
+func (f *fakeGlesysAPI) loadBalancerRemoveFrontend(req *fakeRequest) (string, interface{}, *fakeError) {
+	lb, err := f.loadBalancer(req.str("loadbalancerid"))
+	if err != nil {
+		return "", nil, err
+	}
+	name := req.str("frontendname")
+	for i := range lb.FrontendsList {
+		if lb.FrontendsList[i].Name == name {
+			lb.FrontendsList = append(lb.FrontendsList[:i], lb.FrontendsList[i+1:]...)
+			return "", nil, nil
+		}
+	}
+	return "", nil, fakeNotFound("Frontend %s does not exist", name)
+}
+
+func (f *fakeGlesysAPI) loadBalancerAddTarget(req *fakeRequest) (string, interface{}, *fakeError) {
+	var params struct {
+		glesys.AddTargetParams
+		LoadBalancerID string `json:"loadbalancerid"`
+	}
+	if err := req.decode(&params); err != nil {
+		return "", nil, err
+	}
+
+	lb, err := f.loadBalancer(params.LoadBalancerID)
+	if err != nil {
+		return "", nil, err
+	}
+	backend, err := f.backend(lb, params.Backend)
+	if err != nil {
+		return "", nil, err
+	}
+	if _, err := f.target(backend, params.Name); err == nil {
+		return "", nil, fakeBadRequest("Target %s already exists", params.Name)
+	}
+
+	backend.Targets = append(backend.Targets, glesys.Target{
+		Enabled:  true,
+		Name:     params.Name,
+		Port:     params.Port,
+		Status:   "UP",
+		TargetIP: params.TargetIP,
+		Weight:   params.Weight,
+	})
+	backend.Status = "UP"
+
+	return "loadbalancer", loadBalancerCopy(lb), nil
+}
+
+func (f *fakeGlesysAPI) loadBalancerEditTarget(req *fakeRequest) (string, interface{}, *fakeError) {
+	var params struct {
+		glesys.EditTargetParams
+		LoadBalancerID string `json:"loadbalancerid"`
+	}
+	if err := req.decode(&params); err != nil {
+		return "", nil, err
+	}
+
+	lb, err := f.loadBalancer(params.LoadBalancerID)
+	if err != nil {
+		return "", nil, err
+	}
+	backend, err := f.backend(lb, params.Backend)
+	if err != nil {
+		return "", nil, err
+	}
+	target, err := f.target(backend, params.Name)
+	if err != nil {
+		return "", nil, err
+	}
+	if params.Port != 0 {
+		target.Port = params.Port
+	}
+	if params.TargetIP != "" {
+		target.TargetIP = params.TargetIP
+	}
+	if params.Weight != 0 {
+		target.Weight = params.Weight
+	}
+	return "loadbalancer", loadBalancerCopy(lb), nil
+}
+
+func (f *fakeGlesysAPI) toggleTarget(req *fakeRequest, enabled bool) (string, interface{}, *fakeError) {
+	var params struct {
+		glesys.ToggleTargetParams
+		LoadBalancerID string `json:"loadbalancerid"`
+	}
+	if err := req.decode(&params); err != nil {
+		return "", nil, err
+	}
+
+	lb, err := f.loadBalancer(params.LoadBalancerID)
+	if err != nil {
+		return "", nil, err
+	}
+	backend, err := f.backend(lb, params.Backend)
+	if err != nil {
+		return "", nil, err
+	}
+	target, err := f.target(backend, params.Name)
+	if err != nil {
+		return "", nil, err
+	}
+	target.Enabled = enabled
+	target.Status = "UP"
+	if !enabled {
+		target.Status = "MAINT"
+	}
+	return "loadbalancer", loadBalancerCopy(lb), nil
+}
+
+func (f *fakeGlesysAPI) loadBalancerEnableTarget(req *fakeRequest) (string, interface{}, *fakeError) {
+	return f.toggleTarget(req, true)
+}
+
+func (f *fakeGlesysAPI) loadBalancerDisableTarget(req *fakeRequest) (string, interface{}, *fakeError) {
+	return f.toggleTarget(req, false)
+}
+
+func (f *fakeGlesysAPI) loadBalancerRemoveTarget(req *fakeRequest) (string, interface{}, *fakeError) {
+	var params struct {
+		glesys.RemoveTargetParams
+		LoadBalancerID string `json:"loadbalancerid"`
+	}
+	if err := req.decode(&params); err != nil {
+		return "", nil, err
+	}
+
+	lb, err := f.loadBalancer(params.LoadBalancerID)
+	if err != nil {
+		return "", nil, err
+	}
+	backend, err := f.backend(lb, params.Backend)
+	if err != nil {
+		return "", nil, err
+	}
+	for i := range backend.Targets {
+		if backend.Targets[i].Name == params.Name {
+			backend.Targets = append(backend.Targets[:i], backend.Targets[i+1:]...)
+			return "", nil, nil
+		}
+	}
+	return "", nil, fakeNotFound("Target %s does not exist", params.Name)
+}
+
+// Object storage
+
+func (f *fakeGlesysAPI) objectStorage(id string) (*glesys.ObjectStorageInstance, *fakeError) {
+	instance, ok := f.objectStorages[id]
+	if !ok {
+		return nil, fakeNotFound("Object storage instance %s does not exist", id)
+	}
+	return instance, nil
+}
+
+// objectStorageCopy returns the instance as presented by instancedetails,
+// where secret keys are never included.
+func objectStorageCopy(instance *glesys.ObjectStorageInstance) glesys.ObjectStorageInstance {
+	out := *instance
+	out.Credentials = nil
+	for _, cred := range instance.Credentials {
+		cred.SecretKey = ""
+		out.Credentials = append(out.Credentials, cred)
+	}
+	return out
+}
+
+func (f *fakeGlesysAPI) newCredential(description string) glesys.ObjectStorageCredential {
+	id := f.nextID("cred-")
+	return glesys.ObjectStorageCredential{
+		AccessKey:    strings.ToUpper(strings.ReplaceAll(id, "-", "")) + "ACCESSKEY",
+		Created:      "2026-01-01T00:00:00+01:00",
+		CredentialID: id,
+		Description:  description,
+		SecretKey:    id + "-secret",
+	}
+}
+
+func (f *fakeGlesysAPI) objectStorageCreateInstance(req *fakeRequest) (string, interface{}, *fakeError) {
+	var params glesys.CreateObjectStorageInstanceParams
+	if err := req.decode(&params); err != nil {
+		return "", nil, err
+	}
+
+	instance := &glesys.ObjectStorageInstance{
+		Created:     "2026-01-01T00:00:00+01:00",
+		Credentials: []glesys.ObjectStorageCredential{f.newCredential("")},
+		DataCenter:  params.DataCenter,
+		Description: params.Description,
+		InstanceID:  f.nextID("os-"),
+	}
+	f.objectStorages[instance.InstanceID] = instance
+
+	out := *instance
+	out.Credentials = append([]glesys.ObjectStorageCredential{}, instance.Credentials...)
+	return "instance", out, nil
+}
+
+func (f *fakeGlesysAPI) objectStorageInstanceDetails(req *fakeRequest) (string, interface{}, *fakeError) {
+	instance, err := f.objectStorage(req.str("instanceid"))
+	if err != nil {
+		return "", nil, err
+	}
+	return "instance", objectStorageCopy(instance), nil
+}
+
+func (f *fakeGlesysAPI) objectStorageEditInstance(req *fakeRequest) (string, interface{}, *fakeError) {
+	var params glesys.EditObjectStorageInstanceParams
+	if err := req.decode(&params); err != nil {
+		return "", nil, err
+	}
+
+	instance, err := f.objectStorage(params.InstanceID)
+	if err != nil {
+		return "", nil, err
+	}
+	instance.Description = params.Description
+	return "instance", objectStorageCopy(instance), nil
+}
+
+func (f *fakeGlesysAPI) objectStorageDeleteInstance(req *fakeRequest) (string, interface{}, *fakeError) {
+	instance, err := f.objectStorage(req.str("instanceid"))
+	if err != nil {
+		return "", nil, err
+	}
+	delete(f.objectStorages, instance.InstanceID)
+	return "", nil, nil
+}
+
+func (f *fakeGlesysAPI) objectStorageListInstances(req *fakeRequest) (string, interface{}, *fakeError) {
+	instances := []glesys.ObjectStorageInstance{}
+	for _, instance := range f.objectStorages {
+		instances = append(instances, objectStorageCopy(instance))
+	}
+	return "instances", instances, nil
+}
+
+func (f *fakeGlesysAPI) objectStorageCreateCredential(req *fakeRequest) (string, interface{}, *fakeError) {
+	var params glesys.CreateObjectStorageCredentialParams
+	if err := req.decode(&params); err != nil {
+		return "", nil, err
+	}
+
+	instance, err := f.objectStorage(params.InstanceID)
+	if err != nil {
+		return "", nil, err
+	}
+	cred := f.newCredential(params.Description)
+	instance.Credentials = append(instance.Credentials, cred)
+	return "credential", cred, nil
+}
+
+func (f *fakeGlesysAPI) objectStorageDeleteCredential(req *fakeRequest) (string, interface{}, *fakeError) {
+	var params glesys.DeleteObjectStorageCredentialParams
+	if err := req.decode(&params); err != nil {
+		return "", nil, err
+	}
+
+	instance, err := f.objectStorage(params.InstanceID)
+	if err != nil {
+		return "", nil, err
+	}
+	for i := range instance.Credentials {
+		if instance.Credentials[i].CredentialID == params.CredentialID {
+			instance.Credentials = append(instance.Credentials[:i], instance.Credentials[i+1:]...)
+			return "", nil, nil
+		}
+	}
+	return "", nil, fakeNotFound("Credential %s does not exist", params.CredentialID)
+}
+
+// Private networks
+
+func (f *fakeGlesysAPI) privateNetwork(id string) (*glesys.PrivateNetwork, *fakeError) {
+	network, ok := f.privateNetworks[id]
+	if !ok {
+		return nil, fakeNotFound("Private network %s does not exist", id)
+	}
+	return network, nil
+}
+
+func (f *fakeGlesysAPI) privateNetworkCreate(req *fakeRequest) (string, interface{}, *fakeError) {
+	network := &glesys.PrivateNetwork{
+		ID:   f.nextID("pn-"),
+		Name: req.str("name"),
+	}
+	network.IPv6Aggregate = fmt.Sprintf("2001:db8:%x::/48", f.id)
+	f.privateNetworks[network.ID] = network
+	return "privatenetwork", *network, nil
+}
+
+func (f *fakeGlesysAPI) privateNetworkDetails(req *fakeRequest) (string, interface{}, *fakeError) {
+	network, err := f.privateNetwork(req.str("privatenetworkid"))
+	if err != nil {
+		return "", nil, err
+	}
+	return "privatenetwork", *network, nil
+}
+
+func (f *fakeGlesysAPI) privateNetworkList(req *fakeRequest) (string, interface{}, *fakeError) {
+	networks := []glesys.PrivateNetwork{}
+	for _, network := range f.privateNetworks {
+		networks = append(networks, *network)
+	}
+	return "privatenetworks", networks, nil
+}
+
+func (f *fakeGlesysAPI) privateNetworkEdit(req *fakeRequest) (string, interface{}, *fakeError) {
+	var params glesys.EditPrivateNetworkParams
+	if err := req.decode(&params); err != nil {
+		return "", nil, err
+	}
+
+	network, err := f.privateNetwork(params.ID)
+	if err != nil {
+		return "", nil, err
+	}
+	if params.Name != "" {
+		network.Name = params.Name
+	}
+	return "privatenetwork", *network, nil
+}
+
+func (f *fakeGlesysAPI) privateNetworkDelete(req *fakeRequest) (string, interface{}, *fakeError) {
+	network, err := f.privateNetwork(req.str("privatenetworkid"))
+	if err != nil {
+		return "", nil, err
+	}
+	for _, segment := range f.segments {
+		if segment.privateNetworkID == network.ID {
+			return "", nil, fakeBadRequest("Private network %s still has segments", network.ID)
+		}
+	}
+	delete(f.privateNetworks, network.ID)
+	return "", nil, nil
+}
+
+func (f *fakeGlesysAPI) privateNetworkCreateSegment(req *fakeRequest) (string, interface{}, *fakeError) {
+	var params glesys.CreatePrivateNetworkSegmentParams
+	if err := req.decode(&params); err != nil {
+		return "", nil, err
+	}
+
+	network, err := f.privateNetwork(params.PrivateNetworkID)
+	if err != nil {
+		return "", nil, err
+	}
+
+	segment := &fakeSegment{
+		PrivateNetworkSegment: glesys.PrivateNetworkSegment{
+			ID:         f.nextID("seg-"),
+			Name:       params.Name,
+			IPv4Subnet: params.IPv4Subnet,
+			Platform:   params.Platform,
+			Datacenter: params.Datacenter,
+		},
+		privateNetworkID: network.ID,
+	}
+	segment.IPv6Subnet = strings.Replace(network.IPv6Aggregate, "::/48", fmt.Sprintf(":%x::/64", f.id), 1)
+	f.segments[segment.ID] = segment
+
+	return "privatenetworksegment", segment.PrivateNetworkSegment, nil
+}
+
+func (f *fakeGlesysAPI) privateNetworkEditSegment(req *fakeRequest) (string, interface{}, *fakeError) {
+	var params glesys.EditPrivateNetworkSegmentParams
+	if err := req.decode(&params); err != nil {
+		return "", nil, err
+	}
+
+	segment, ok := f.segments[params.ID]
+	if !ok {
+		return "", nil, fakeNotFound("Segment %s does not exist", params.ID)
+	}
+	if params.Name != "" {
+		segment.Name = params.Name
+	}
+	return "privatenetworksegment", segment.PrivateNetworkSegment, nil
+}
+
+func (f *fakeGlesysAPI) privateNetworkListSegments(req *fakeRequest) (string, interface{}, *fakeError) {
+	network, err := f.privateNetwork(req.str("privatenetworkid"))
+	if err != nil {
+		return "", nil, err
+	}
+
+	segments := []glesys.PrivateNetworkSegment{}
+	for _, segment := range f.segments {
+		if segment.privateNetworkID == network.ID {
+			segments = append(segments, segment.PrivateNetworkSegment)
+		}
+	}
+	return "privatenetworksegments", segments, nil
+}
+
+func (f *fakeGlesysAPI) privateNetworkDeleteSegment(req *fakeRequest) (string, interface{}, *fakeError) {
+	id := req.str("id")
+	if _, ok := f.segments[id]; !ok {
+		return "", nil, fakeNotFound("Segment %s does not exist", id)
+	}
+	delete(f.segments, id)
+	return "", nil, nil
+}
+
+func TestFakeGlesysAPI(t *testing.T) {
+	api, client := newFakeClient(t)
+	ctx := context.Background()
+
+	srv, err := client.Servers.Create(ctx, glesys.CreateServerParams{
+		Bandwidth:  100,
+		CPU:        1,
+		DataCenter: "Falkenberg",
+		Hostname:   "fake-server",
+		IPv4:       "any",
+		IPv6:       "any",
+		Memory:     1024,
+		Platform:   "KVM",
+		Storage:    20,
+		Template:   "debian-12",
+	})
+	if err != nil {
+		t.Fatalf("could not create server: %s", err)
+	}
+	if len(srv.IPList) != 2 {
+		t.Fatalf("expected 2 ips, got %d", len(srv.IPList))
+	}
+	if srv.InitialTemplate.Name != "Debian 12 (Bookworm)" {
+		t.Fatalf("expected template to be resolved from tag, got %q", srv.InitialTemplate.Name)
+	}
+
+	if _, err := client.Servers.Edit(ctx, srv.ID, glesys.EditServerParams{Memory: 2048}); err != nil {
+		t.Fatalf("could not edit server: %s", err)
+	}
+	details, err := client.Servers.Details(ctx, srv.ID)
+	if err != nil {
+		t.Fatalf("could not fetch server: %s", err)
+	}
+	if details.Memory != 2048 {
+		t.Fatalf("expected memory 2048, got %d", details.Memory)
+	}
+
+	if err := client.Servers.Destroy(ctx, srv.ID, glesys.DestroyServerParams{KeepIP: true}); err != nil {
+		t.Fatalf("could not destroy server: %s", err)
+	}
+	if _, err := client.Servers.Details(ctx, srv.ID); err == nil || !strings.Contains(err.Error(), "404") {
+		t.Fatalf("expected 404 for destroyed server, got %v", err)
+	}
+
+	ips, err := client.IPs.Reserved(ctx, glesys.ReservedIPsParams{})
+	if err != nil {
+		t.Fatalf("could not list reserved ips: %s", err)
+	}
+	if len(*ips) != 2 {
+		t.Fatalf("expected 2 kept ips, got %d", len(*ips))
+	}
+
+	lb, err := client.LoadBalancers.Create(ctx, glesys.CreateLoadBalancerParams{DataCenter: "Falkenberg", Name: "fake-lb"})
+	if err != nil {
+		t.Fatalf("could not create load balancer: %s", err)
+	}
+	if _, err := client.LoadBalancers.AddBackend(ctx, lb.ID, glesys.AddBackendParams{Name: "be"}); err != nil {
+		t.Fatalf("could not add backend: %s", err)
+	}
+	if _, err := client.LoadBalancers.AddTarget(ctx, lb.ID, glesys.AddTargetParams{Backend: "be", Name: "web", Port: 80, TargetIP: "203.0.113.10", Weight: 5}); err != nil {
+		t.Fatalf("could not add target: %s", err)
+	}
+	if _, err := client.LoadBalancers.DisableTarget(ctx, lb.ID, glesys.ToggleTargetParams{Backend: "be", Name: "web"}); err != nil {
+		t.Fatalf("could not disable target: %s", err)
+	}
+	lb, err = client.LoadBalancers.Details(ctx, lb.ID)
+	if err != nil {
+		t.Fatalf("could not fetch load balancer: %s", err)
+	}
+	if target := lb.BackendsList[0].Targets[0]; target.Enabled || target.TargetIP != "203.0.113.10" {
+		t.Fatalf("unexpected target state: %+v", target)
+	}
+
+	unauthenticated := glesys.NewClient("", "", "tf-glesys-test")
+	unauthenticated.SetBaseURL(api.URL)
+	if _, err := unauthenticated.Servers.List(ctx); err == nil {
+		t.Fatal("expected request without credentials to fail")
+	}
+}
+
+// exceedServerLimit makes the server exceed the limit name.
+func (f *fakeGlesysAPI) exceedServerLimit(serverID string, name string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.limits(f.servers[serverID])[name].Failcount++
+}
+
+// failNext makes the next call to endpoint fail with err.
+func (f *fakeGlesysAPI) failNext(endpoint string, err *fakeError) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.failures[endpoint] = err
+}
+
+// callCount returns the number of requests made to endpoint.
+func (f *fakeGlesysAPI) callCount(endpoint string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.calls[endpoint]
+}
+
+// setQuickReboots sets whether reboots finish before the next poll.
+func (f *fakeGlesysAPI) setQuickReboots(quick bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.quickReboots = quick
+}
+
+// rebooting reports whether a reboot of the server hasn't finished yet.
+func (f *fakeGlesysAPI) rebooting(serverID string) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	_, ok := f.reboots[serverID]
+	return ok
+}
@@ -0,0 +1,49 @@
+package glesys
+
+import (
+	"log"
+	"sync"
+)
+
+// mutexKV is a store of mutexes by key. It is used to serialize operations
+// that share a key, like all changes to the same server, while operations on
+// other keys run in parallel.
+type mutexKV struct {
+	lock  sync.Mutex
+	store map[string]*sync.Mutex
+}
+
+func newMutexKV() *mutexKV {
+	return &mutexKV{store: make(map[string]*sync.Mutex)}
+}
+
+// Lock locks the mutex for key, creating it if needed.
+func (m *mutexKV) Lock(key string) {
+	log.Printf("[DEBUG] Locking %q", key)
+	m.get(key).Lock()
+	log.Printf("[DEBUG] Locked %q", key)
+}
+
+// Unlock unlocks the mutex for key.
+func (m *mutexKV) Unlock(key string) {
+	log.Printf("[DEBUG] Unlocking %q", key)
+	m.get(key).Unlock()
+	log.Printf("[DEBUG] Unlocked %q", key)
+}
+
+func (m *mutexKV) get(key string) *sync.Mutex {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	mutex, ok := m.store[key]
+	if !ok {
+		mutex = &sync.Mutex{}
+		m.store[key] = mutex
+	}
+	return mutex
+}
+
+// serverMutexKV serializes all operations that change a server. The API locks
+// a server while a change is applied, so concurrent changes to the same server
+// fail.
+var serverMutexKV = newMutexKV()
@@ -0,0 +1,51 @@
+package glesys
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestMutexKV(t *testing.T) {
+	m := newMutexKV()
+
+	var running, overlapping int32
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			m.Lock("kvm123")
+			defer m.Unlock("kvm123")
+
+			if n := atomic.AddInt32(&running, 1); n > 1 {
+				atomic.StoreInt32(&overlapping, n)
+			}
+			time.Sleep(5 * time.Millisecond)
+			atomic.AddInt32(&running, -1)
+		}()
+	}
+	wg.Wait()
+
+	if overlapping != 0 {
+		t.Errorf("expected operations on the same key to run one at a time, got %d at once", overlapping)
+	}
+
+	// A held key must not block other keys.
+	m.Lock("kvm123")
+	defer m.Unlock("kvm123")
+
+	done := make(chan struct{})
+	go func() {
+		m.Lock("kvm456")
+		m.Unlock("kvm456")
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("lock on another key was blocked")
+	}
+}
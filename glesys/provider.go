@@ -1,7 +1,10 @@
 package glesys
 
 import (
+	"time"
+
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 // Provider - Setup new Terraform Provider resource
@@ -27,13 +30,49 @@ func Provider() *schema.Provider {
 				DefaultFunc: schema.EnvDefaultFunc("GLESYS_API_URL", "https://api.glesys.com"),
 				Description: "The base URL to use for the Glesys API requests. (Defaults to the value of the `GLESYS_API_URL` environment variable or `https://api.glesys.com` if unset.",
 			},
+			"max_retries": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      defaultMaxRetries,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Maximum number of times an API request is retried when rate limited, when the API is unavailable or when the connection to the API fails. Requests that only read are also retried on gateway errors and other connection failures. Set to `0` to disable retries.",
+			},
+			"retry_max_wait": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      int(defaultRetryMaxWait.Seconds()),
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "Maximum number of seconds to wait between retries of an API request.",
+			},
+			"max_concurrent_requests": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Maximum number of API requests sent at the same time. Defaults to `0`, no limit.",
+			},
+			"cost_warning_threshold": {
+				Type:         schema.TypeFloat,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.FloatAtLeast(0),
+				Description:  "Show a warning when the estimated monthly cost of a `glesys_server`, `glesys_database` or `glesys_server_disk` is above this amount. Terraform providers built on the plugin SDK can't add warnings to a plan, so the warning is shown after the change is applied, and only logged at `WARN` level during the plan. Defaults to `0`, no warning.",
+			},
 		},
 
 		DataSourcesMap: map[string]*schema.Resource{
+			"glesys_cloudconfig":    dataSourceGlesysCloudConfig(),
 			"glesys_dnsdomain":      dataSourceGlesysDNSDomain(),
 			"glesys_ip":             dataSourceGlesysIP(),
 			"glesys_network":        dataSourceGlesysNetwork(),
 			"glesys_networkadapter": dataSourceGlesysNetworkAdapter(),
+			"glesys_server":         dataSourceGlesysServer(),
+			"glesys_server_backups": dataSourceGlesysServerBackups(),
+			"glesys_server_console": dataSourceGlesysServerConsole(),
+			"glesys_server_isos":    dataSourceGlesysServerISOs(),
+			"glesys_server_status":  dataSourceGlesysServerStatus(),
+			"glesys_servers":        dataSourceGlesysServers(),
+			"glesys_templates":      dataSourceGlesysTemplates(),
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
@@ -50,6 +89,9 @@ func Provider() *schema.Provider {
 			"glesys_networkadapter":           resourceGlesysNetworkAdapter(),
 			"glesys_server":                   resourceGlesysServer(),
 			"glesys_server_disk":              resourceGlesysServerDisk(),
+			"glesys_server_ip_attachment":     resourceGlesysServerIPAttachment(),
+			"glesys_server_iso":               resourceGlesysServerISO(),
+			"glesys_server_limits":            resourceGlesysServerLimits(),
 			"glesys_objectstorage_instance":   resourceGlesysObjectStorageInstance(),
 			"glesys_objectstorage_credential": resourceGlesysObjectStorageCredential(),
 			"glesys_privatenetwork":           resourceGlesysPrivateNetwork(),
@@ -63,9 +105,14 @@ func Provider() *schema.Provider {
 
 func providerConfigure(d *schema.ResourceData) (interface{}, error) {
 	config := Config{
-		UserID:      d.Get("userid").(string),
-		Token:       d.Get("token").(string),
-		APIEndpoint: d.Get("api_endpoint").(string),
+		UserID:       d.Get("userid").(string),
+		Token:        d.Get("token").(string),
+		APIEndpoint:  d.Get("api_endpoint").(string),
+		MaxRetries:   d.Get("max_retries").(int),
+		RetryMaxWait: time.Duration(d.Get("retry_max_wait").(int)) * time.Second,
+
+		MaxConcurrentRequests: d.Get("max_concurrent_requests").(int),
+		CostWarningThreshold:  d.Get("cost_warning_threshold").(float64),
 	}
 	return config.Client()
 }
@@ -4,10 +4,10 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"os/exec"
 	"strings"
 	"testing"
 
-	"github.com/glesys/glesys-go/v8"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -19,6 +19,10 @@ const testNamePrefix = "tf-acc-test-"
 var testGlesysProvider *schema.Provider
 var testGlesysProviders map[string]*schema.Provider
 
+// testFakeAPI is the in-process GleSYS API the tests run against unless
+// TF_ACC is set.
+var testFakeAPI *fakeGlesysAPI
+
 func init() {
 	testGlesysProvider = Provider()
 	testGlesysProviders = map[string]*schema.Provider{
@@ -26,6 +30,25 @@ func init() {
 	}
 }
 
+func TestMain(m *testing.M) {
+	// Without TF_ACC the resource tests run against the fake API. The
+	// environment is set for the whole process since the provider reads its
+	// configuration from it and several tests run in parallel.
+	if os.Getenv("TF_ACC") == "" {
+		testFakeAPI = newFakeGlesysAPI()
+		os.Setenv("GLESYS_API_URL", testFakeAPI.URL)
+		os.Setenv("GLESYS_USERID", "cl12345")
+		os.Setenv("GLESYS_TOKEN", "fake-token")
+	}
+
+	code := m.Run()
+
+	if testFakeAPI != nil {
+		testFakeAPI.Close()
+	}
+	os.Exit(code)
+}
+
 func TestProvider(t *testing.T) {
 	if err := Provider().InternalValidate(); err != nil {
 		t.Fatalf("err: %s", err)
@@ -56,7 +79,7 @@ func TestProviderURLOverride(t *testing.T) {
 		t.Fatalf("Expected metadata, got nil")
 	}
 
-	client := meta.(*glesys.Client)
+	client := meta.(*apiClient)
 	if client.BaseURL.String() != apiURL {
 		t.Fatalf("Expected %s, got %s", apiURL, client.BaseURL.String())
 	}
@@ -64,7 +87,10 @@ func TestProviderURLOverride(t *testing.T) {
 
 func testAccPreCheck(t *testing.T) {
 	if v := os.Getenv("TF_ACC"); v == "" {
-		t.Skip("TF_ACC not set, skipping acceptance tests")
+		if !testTerraformAvailable() {
+			t.Skip("TF_ACC not set and no terraform binary found, skipping tests against the fake API")
+		}
+		return
 	}
 
 	if v := os.Getenv("GLESYS_USERID"); v == "" {
@@ -75,6 +101,16 @@ func testAccPreCheck(t *testing.T) {
 	}
 }
 
+// testTerraformAvailable reports whether the testing framework can find a
+// terraform binary without downloading one.
+func testTerraformAvailable() bool {
+	if os.Getenv("TF_ACC_TERRAFORM_PATH") != "" || os.Getenv("TF_ACC_TERRAFORM_VERSION") != "" {
+		return true
+	}
+	_, err := exec.LookPath("terraform")
+	return err == nil
+}
+
 func randomTestName(additionalNames ...string) string {
 	prefix := testNamePrefix
 	for _, n := range additionalNames {
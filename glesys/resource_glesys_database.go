@@ -3,6 +3,7 @@ package glesys
 import (
 	"context"
 	"fmt"
+	"log"
 	"strconv"
 	"strings"
 	"time"
@@ -19,10 +20,17 @@ func resourceGlesysDatabase() *schema.Resource {
 		UpdateContext: resourceGlesysDatabaseUpdate,
 		ReadContext:   resourceGlesysDatabaseRead,
 		DeleteContext: resourceGlesysDatabaseDelete,
+		CustomizeDiff: resourceGlesysDatabaseEstimateCost,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
 
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+
 		Schema: map[string]*schema.Schema{
 			"id": {
 				Description: "Database ID",
@@ -53,6 +61,7 @@ func resourceGlesysDatabase() *schema.Resource {
 				Required:    true,
 				ForceNew:    true,
 			},
+			"estimated_cost": estimatedCostSchema(),
 			"fqdn": {
 				Description: "Database FQDN",
 				Type:        schema.TypeString,
@@ -100,7 +109,7 @@ func resourceGlesysDatabase() *schema.Resource {
 }
 
 func resourceGlesysDatabaseCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*glesys.Client)
+	client := m.(*apiClient)
 
 	// Create a database in the Glesys platform
 	rawAllowlist := d.Get("allowlist").([]interface{})
@@ -124,11 +133,32 @@ func resourceGlesysDatabaseCreate(ctx context.Context, d *schema.ResourceData, m
 
 	// Set the Id to domain.ID
 	d.SetId(database.ID)
-	if _, err = waitForDatabaseAttribute(ctx, d, "true", []string{"false"}, "RUNNING", m); err != nil {
+	if _, err = waitForDatabaseAttribute(ctx, d, "true", []string{"false"}, "RUNNING", d.Timeout(schema.TimeoutCreate), m); err != nil {
 		return diag.Errorf("error while waiting for database (%s) to be started: %s", d.Id(), err)
 	}
 
-	return resourceGlesysDatabaseRead(ctx, d, m)
+	diags := resourceGlesysDatabaseRead(ctx, d, m)
+	return append(diags, costWarning(d, m, "database")...)
+}
+
+// resourceGlesysDatabaseEstimateCost plans estimated_cost for new databases.
+func resourceGlesysDatabaseEstimateCost(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	client := m.(*apiClient)
+
+	if !needsCostEstimate(d, "plankey") {
+		return keepCostEstimate(d)
+	}
+
+	billing, err := client.Databases.EstimatedCost(ctx, glesys.EstimatedCostParams{
+		ID:      d.Id(),
+		PlanKey: d.Get("plankey").(string),
+	})
+	if err != nil {
+		log.Printf("[WARN] unable to estimate the cost of database (%s): %s", d.Id(), err)
+		return nil
+	}
+
+	return setEstimatedCost(d, client, "database", billing)
 }
 
 func convertResourceDataToListOfStrings(raw []interface{}) ([]string, error) {
@@ -144,7 +174,7 @@ func convertResourceDataToListOfStrings(raw []interface{}) ([]string, error) {
 }
 
 func updateAllowlist(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*glesys.Client)
+	client := m.(*apiClient)
 
 	rawAllowlist := d.Get("allowlist").([]interface{})
 	allowlist, err := convertResourceDataToListOfStrings(rawAllowlist)
@@ -160,27 +190,23 @@ func updateAllowlist(ctx context.Context, d *schema.ResourceData, m interface{})
 		return diag.FromErr(fmt.Errorf("failed to update allowlist: %w", err))
 	}
 
-	if _, err := waitForDatabaseAttribute(ctx, d, "true", []string{"false"}, "RUNNING", m); err != nil {
+	if _, err := waitForDatabaseAttribute(ctx, d, "true", []string{"false"}, "RUNNING", d.Timeout(schema.TimeoutUpdate), m); err != nil {
 		return diag.Errorf("error while waiting for database (%s) to be started: %s", d.Id(), err)
 	}
 	return nil
 }
 
 func resourceGlesysDatabaseRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*glesys.Client)
+	client := m.(*apiClient)
 
 	database, err := client.Databases.Details(ctx, d.Id())
 	if err != nil {
-		diag.Errorf("database not found: %v", err)
-		d.SetId("")
-		return nil
+		return readError(d, err, "database")
 	}
 	connectionstring, err := client.Databases.ConnectionString(ctx, d.Id())
 
 	if err != nil {
-		diag.Errorf("could not get connectionstring: %v", err)
-		d.SetId("")
-		return nil
+		return readError(d, err, "database")
 	}
 
 	d.Set("id", database.ID)
@@ -201,13 +227,17 @@ func resourceGlesysDatabaseRead(ctx context.Context, d *schema.ResourceData, m i
 }
 
 func resourceGlesysDatabaseUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	updateAllowlist(ctx, d, m)
+	if d.HasChange("allowlist") {
+		if diags := updateAllowlist(ctx, d, m); diags.HasError() {
+			return diags
+		}
+	}
 
-	return nil
+	return resourceGlesysDatabaseRead(ctx, d, m)
 }
 
 func resourceGlesysDatabaseDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*glesys.Client)
+	client := m.(*apiClient)
 
 	err := client.Databases.Delete(ctx, d.Id())
 	if err != nil {
@@ -217,22 +247,23 @@ func resourceGlesysDatabaseDelete(ctx context.Context, d *schema.ResourceData, m
 	return nil
 }
 
-// waitForDatabaseAttribute
+// waitForDatabaseAttribute waits until the database status is attribute, or
+// timeout is reached.
 func waitForDatabaseAttribute(
-	ctx context.Context, d *schema.ResourceData, target string, pending []string, attribute string, m interface{}) (interface{}, error) {
-	stateConf := &retry.StateChangeConf{
-		Pending:    pending,
-		Target:     []string{target},
-		Refresh:    databaseStateRefresh(ctx, d, m, attribute),
-		Timeout:    20 * time.Minute,
-		Delay:      6 * time.Second,
-		MinTimeout: 3 * time.Second,
-	}
+	ctx context.Context, d *schema.ResourceData, target string, pending []string, attribute string, timeout time.Duration, m interface{}) (interface{}, error) {
+	client := m.(*apiClient)
+
+	stateConf := client.stateChangeConf(&retry.StateChangeConf{
+		Pending: pending,
+		Target:  []string{target},
+		Refresh: databaseStateRefresh(ctx, d, m, attribute),
+		Timeout: timeout,
+	}, 6*time.Second, 3*time.Second)
 	return stateConf.WaitForStateContext(ctx)
 }
 
 func databaseStateRefresh(ctx context.Context, d *schema.ResourceData, m interface{}, attr string) retry.StateRefreshFunc {
-	client := m.(*glesys.Client)
+	client := m.(*apiClient)
 	return func() (interface{}, string, error) {
 		// check state of database
 		database, err := client.Databases.Details(ctx, d.Id())
@@ -9,7 +9,7 @@ import (
 func TestAccGlesysDatabase_basic(t *testing.T) {
 	resourceName := "glesys_database.test"
 
-	resource.Test(t, resource.TestCase{
+	resource.UnitTest(t, resource.TestCase{
 		PreCheck:  func() { testAccPreCheck(t) },
 		Providers: testGlesysProviders,
 		Steps: []resource.TestStep{
@@ -136,7 +136,7 @@ func resourceGlesysDNSDomain() *schema.Resource {
 }
 
 func resourceGlesysDNSDomainCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*glesys.Client)
+	client := m.(*apiClient)
 
 	// Add a domain in the glesys platform. Do not register new domains right now.
 	params := glesys.AddDNSDomainParams{
@@ -163,14 +163,12 @@ func resourceGlesysDNSDomainCreate(ctx context.Context, d *schema.ResourceData,
 }
 
 func resourceGlesysDNSDomainRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*glesys.Client)
+	client := m.(*apiClient)
 
 	domain, err := client.DNSDomains.Details(ctx, d.Id())
 
 	if err != nil {
-		diag.Errorf("domain not found: %v", err)
-		d.SetId("")
-		return nil
+		return readError(d, err, "domain")
 	}
 
 	d.Set("createtime", domain.CreateTime)
@@ -197,7 +195,7 @@ func resourceGlesysDNSDomainRead(ctx context.Context, d *schema.ResourceData, m
 }
 
 func resourceGlesysDNSDomainUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*glesys.Client)
+	client := m.(*apiClient)
 
 	params := glesys.EditDNSDomainParams{Name: d.Id()}
 
@@ -238,7 +236,7 @@ func resourceGlesysDNSDomainUpdate(ctx context.Context, d *schema.ResourceData,
 }
 
 func resourceGlesysDNSDomainDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*glesys.Client)
+	client := m.(*apiClient)
 
 	params := glesys.DeleteDNSDomainParams{
 		Name: d.Id(),
@@ -3,6 +3,7 @@ package glesys
 import (
 	"context"
 	"fmt"
+	"log"
 	"strconv"
 	"strings"
 
@@ -82,7 +83,7 @@ func resourceGlesysRecordImport(ctx context.Context, d *schema.ResourceData, m i
 }
 
 func resourceGlesysDNSDomainRecordCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*glesys.Client)
+	client := m.(*apiClient)
 
 	params := glesys.AddRecordParams{
 		Data:       d.Get("data").(string),
@@ -105,7 +106,7 @@ func resourceGlesysDNSDomainRecordCreate(ctx context.Context, d *schema.Resource
 }
 
 func resourceGlesysDNSDomainRecordRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*glesys.Client)
+	client := m.(*apiClient)
 
 	domain := d.Get("domain").(string)
 	myID, err := strconv.Atoi(d.Id())
@@ -113,8 +114,12 @@ func resourceGlesysDNSDomainRecordRead(ctx context.Context, d *schema.ResourceDa
 		return diag.Errorf("invalid record id: %v", err)
 	}
 
-	record, err := findRecordByID(client, domain, myID)
+	record, err := findRecordByID(ctx, client, domain, myID)
 	if err != nil {
+		return readError(d, err, "domain record")
+	}
+	if record == nil {
+		log.Printf("[WARN] domain record (%s) not found, removing from state", d.Id())
 		d.SetId("")
 		return nil
 	}
@@ -130,7 +135,7 @@ func resourceGlesysDNSDomainRecordRead(ctx context.Context, d *schema.ResourceDa
 }
 
 func resourceGlesysDNSDomainRecordUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*glesys.Client)
+	client := m.(*apiClient)
 
 	myID := d.Id()
 	recordID, errid := strconv.Atoi(myID)
@@ -164,7 +169,7 @@ func resourceGlesysDNSDomainRecordUpdate(ctx context.Context, d *schema.Resource
 }
 
 func resourceGlesysDNSDomainRecordDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*glesys.Client)
+	client := m.(*apiClient)
 
 	recordID, errid := strconv.Atoi(d.Id())
 	if errid != nil {
@@ -173,7 +178,7 @@ func resourceGlesysDNSDomainRecordDelete(ctx context.Context, d *schema.Resource
 
 	err := client.DNSDomains.DeleteRecord(ctx, recordID)
 	if err != nil {
-		if strings.Contains(err.Error(), "HTTP error: 404") {
+		if isNotFoundError(err) {
 			d.SetId("")
 			return nil
 		} else {
@@ -184,10 +189,12 @@ func resourceGlesysDNSDomainRecordDelete(ctx context.Context, d *schema.Resource
 	return nil
 }
 
-func findRecordByID(client *glesys.Client, domain string, id int) (*glesys.DNSDomainRecord, error) {
-	records, err := client.DNSDomains.ListRecords(context.Background(), domain)
+// findRecordByID returns the record with the given id, or nil if the domain
+// has no such record.
+func findRecordByID(ctx context.Context, client *apiClient, domain string, id int) (*glesys.DNSDomainRecord, error) {
+	records, err := client.DNSDomains.ListRecords(ctx, domain)
 	if err != nil {
-		return nil, fmt.Errorf("domain not found: %s", err)
+		return nil, err
 	}
 
 	for _, rec := range *records {
@@ -196,5 +203,5 @@ func findRecordByID(client *glesys.Client, domain string, id int) (*glesys.DNSDo
 		}
 	}
 
-	return nil, fmt.Errorf("no record found for ID %d", id)
+	return nil, nil
 }
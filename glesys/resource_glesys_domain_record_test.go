@@ -0,0 +1,63 @@
+package glesys
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccGlesysDNSDomainRecord_basic(t *testing.T) {
+	domainName := randomTestName() + ".com"
+
+	name := "glesys_dnsdomain_record.test"
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testGlesysProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccGlesysDNSDomainRecord(domainName, "192.0.2.10", 3600),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "domain", domainName),
+					resource.TestCheckResourceAttr(name, "host", "www"),
+					resource.TestCheckResourceAttr(name, "type", "A"),
+					resource.TestCheckResourceAttr(name, "data", "192.0.2.10"),
+					resource.TestCheckResourceAttr(name, "ttl", "3600"),
+					resource.TestCheckResourceAttrSet(name, "recordid"),
+				),
+			},
+			{
+				Config: testAccGlesysDNSDomainRecord(domainName, "192.0.2.20", 300),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "data", "192.0.2.20"),
+					resource.TestCheckResourceAttr(name, "ttl", "300"),
+				),
+			},
+			{
+				ResourceName:      name,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					rs := s.RootModule().Resources[name]
+					return fmt.Sprintf("%s,%s", rs.Primary.Attributes["domain"], rs.Primary.ID), nil
+				},
+			},
+		},
+	})
+}
+
+func testAccGlesysDNSDomainRecord(domain, data string, ttl int) string {
+	return fmt.Sprintf(`
+		resource "glesys_dnsdomain" "test" {
+			name = "%s"
+		}
+
+		resource "glesys_dnsdomain_record" "test" {
+			domain = glesys_dnsdomain.test.name
+			host   = "www"
+			type   = "A"
+			data   = "%s"
+			ttl    = %d
+		} `, domain, data, ttl)
+}
@@ -101,7 +101,7 @@ func resourceGlesysEmailAccount() *schema.Resource {
 }
 
 func resourceGlesysEmailAccountCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*glesys.Client)
+	client := m.(*apiClient)
 
 	params := glesys.CreateAccountParams{
 		EmailAccount:       d.Get("emailaccount").(string),
@@ -123,15 +123,14 @@ func resourceGlesysEmailAccountCreate(ctx context.Context, d *schema.ResourceDat
 }
 
 func resourceGlesysEmailAccountRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*glesys.Client)
+	client := m.(*apiClient)
 
 	components := strings.Split(d.Id(), "@")
 	domain := components[1]
 	listParams := glesys.ListEmailsParams{Filter: d.Id()}
 	accounts, err := client.EmailDomains.List(ctx, domain, listParams)
 	if err != nil {
-		diag.Errorf("Error listing email domains for domain (%s): %s", domain, err)
-		return nil
+		return readError(d, err, "email account")
 	}
 	if len(accounts.EmailAccounts) == 1 {
 		log.Printf("[INFO] Found account: %s", accounts.EmailAccounts[0].EmailAccount)
@@ -157,7 +156,7 @@ func resourceGlesysEmailAccountRead(ctx context.Context, d *schema.ResourceData,
 }
 
 func resourceGlesysEmailAccountUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*glesys.Client)
+	client := m.(*apiClient)
 
 	params := glesys.EditAccountParams{}
 
@@ -193,7 +192,7 @@ func resourceGlesysEmailAccountUpdate(ctx context.Context, d *schema.ResourceDat
 }
 
 func resourceGlesysEmailAccountDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*glesys.Client)
+	client := m.(*apiClient)
 
 	err := client.EmailDomains.Delete(ctx, d.Id())
 	if err != nil {
@@ -38,7 +38,7 @@ func resourceGlesysEmailAlias() *schema.Resource {
 }
 
 func resourceGlesysEmailAliasCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*glesys.Client)
+	client := m.(*apiClient)
 
 	params := glesys.EmailAliasParams{
 		EmailAlias: d.Get("emailalias").(string),
@@ -55,15 +55,14 @@ func resourceGlesysEmailAliasCreate(ctx context.Context, d *schema.ResourceData,
 }
 
 func resourceGlesysEmailAliasRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*glesys.Client)
+	client := m.(*apiClient)
 
 	components := strings.Split(d.Id(), "@")
 	domain := components[1]
 	listParams := glesys.ListEmailsParams{Filter: d.Id()}
 	accounts, err := client.EmailDomains.List(ctx, domain, listParams)
 	if err != nil {
-		diag.Errorf("Error listing email domains for domain (%s): %s", domain, err)
-		return nil
+		return readError(d, err, "email alias")
 	}
 	if len(accounts.EmailAliases) == 1 {
 		log.Printf("[INFO] Found alias: %s", accounts.EmailAliases[0].EmailAlias)
@@ -80,7 +79,7 @@ func resourceGlesysEmailAliasRead(ctx context.Context, d *schema.ResourceData, m
 }
 
 func resourceGlesysEmailAliasUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*glesys.Client)
+	client := m.(*apiClient)
 
 	params := glesys.EmailAliasParams{
 		EmailAlias: d.Id(),
@@ -98,7 +97,7 @@ func resourceGlesysEmailAliasUpdate(ctx context.Context, d *schema.ResourceData,
 }
 
 func resourceGlesysEmailAliasDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*glesys.Client)
+	client := m.(*apiClient)
 
 	err := client.EmailDomains.Delete(ctx, d.Id())
 	if err != nil {
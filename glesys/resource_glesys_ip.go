@@ -3,7 +3,6 @@ package glesys
 import (
 	"context"
 	"math/rand"
-	"strings"
 	"time"
 
 	"github.com/glesys/glesys-go/v8"
@@ -126,7 +125,7 @@ func resourceGlesysIP() *schema.Resource {
 
 func resourceGlesysIPCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	// Setup client to the API
-	client := m.(*glesys.Client)
+	client := m.(*apiClient)
 
 	address := d.Get("address").(string)
 	if address == "" {
@@ -169,14 +168,12 @@ func resourceGlesysIPCreate(ctx context.Context, d *schema.ResourceData, m inter
 }
 
 func resourceGlesysIPRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*glesys.Client)
+	client := m.(*apiClient)
 
 	// Fetch updates about the IP
 	ip, err := client.IPs.Details(ctx, d.Id())
 	if err != nil {
-		diag.Errorf("IP not found: %s", err)
-		d.SetId("")
-		return nil
+		return readError(d, err, "IP")
 	}
 
 	d.Set("address", ip.Address)
@@ -206,7 +203,7 @@ func resourceGlesysIPRead(ctx context.Context, d *schema.ResourceData, m interfa
 }
 
 func resourceGlesysIPUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*glesys.Client)
+	client := m.(*apiClient)
 
 	if d.HasChange("ptr") {
 		// There should be support here for resetting pointer records when they are zeroed.
@@ -224,11 +221,11 @@ func resourceGlesysIPUpdate(ctx context.Context, d *schema.ResourceData, m inter
 }
 
 func resourceGlesysIPDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*glesys.Client)
+	client := m.(*apiClient)
 
 	err := client.IPs.Release(ctx, d.Id())
 	if err != nil {
-		if strings.Contains(err.Error(), "HTTP error: 404") {
+		if isNotFoundError(err) {
 			return nil
 		} else {
 			return diag.Errorf("Error releasing IP %s: %v", d.Id(), err)
@@ -6,7 +6,6 @@ import (
 	"strings"
 	"testing"
 
-	"github.com/glesys/glesys-go/v8"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
@@ -90,7 +89,7 @@ func TestAccIP_updatePTR(t *testing.T) {
 }
 
 func testAccIPResourceDestroy(s *terraform.State) error {
-	client := testGlesysProvider.Meta().(*glesys.Client)
+	client := testGlesysProvider.Meta().(*apiClient)
 
 	for _, resource := range s.RootModule().Resources {
 		if resource.Type != "glesys_ip" {
@@ -2,6 +2,9 @@ package glesys
 
 import (
 	"context"
+	"fmt"
+	"strings"
+	"time"
 
 	"github.com/glesys/glesys-go/v8"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -14,9 +17,18 @@ func resourceGlesysLoadBalancer() *schema.Resource {
 		ReadContext:   resourceGlesysLoadBalancerRead,
 		UpdateContext: resourceGlesysLoadBalancerUpdate,
 		DeleteContext: resourceGlesysLoadBalancerDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
 
 		Description: "Create a LoadBalancer",
 
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+
 		Schema: map[string]*schema.Schema{
 			"datacenter": {
 				Description: "LoadBalancer datacenter. `Falkenberg`, `Stockholm`",
@@ -53,7 +65,7 @@ func resourceGlesysLoadBalancer() *schema.Resource {
 }
 
 func resourceGlesysLoadBalancerCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*glesys.Client)
+	client := m.(*apiClient)
 
 	params := glesys.CreateLoadBalancerParams{
 		DataCenter: d.Get("datacenter").(string),
@@ -72,13 +84,11 @@ func resourceGlesysLoadBalancerCreate(ctx context.Context, d *schema.ResourceDat
 }
 
 func resourceGlesysLoadBalancerRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*glesys.Client)
+	client := m.(*apiClient)
 
 	loadbalancer, err := client.LoadBalancers.Details(ctx, d.Id())
 	if err != nil {
-		diag.Errorf("loadbalancer not found: %s", err)
-		d.SetId("")
-		return nil
+		return readError(d, err, "loadbalancer")
 	}
 
 	var ipAddresses []string
@@ -102,7 +112,7 @@ func resourceGlesysLoadBalancerRead(ctx context.Context, d *schema.ResourceData,
 }
 
 func resourceGlesysLoadBalancerUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*glesys.Client)
+	client := m.(*apiClient)
 
 	params := glesys.EditLoadBalancerParams{}
 
@@ -119,7 +129,7 @@ func resourceGlesysLoadBalancerUpdate(ctx context.Context, d *schema.ResourceDat
 }
 
 func resourceGlesysLoadBalancerDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*glesys.Client)
+	client := m.(*apiClient)
 
 	err := client.LoadBalancers.Destroy(ctx, d.Id())
 	if err != nil {
@@ -128,3 +138,52 @@ func resourceGlesysLoadBalancerDelete(ctx context.Context, d *schema.ResourceDat
 	d.SetId("")
 	return nil
 }
+
+// loadBalancerChildID returns the ID of a frontend, backend or target, on the
+// form "<loadbalancerid>/<name>" or "<loadbalancerid>/<backend>/<target>".
+// Names are only unique within a loadbalancer, so the name alone can't be used.
+func loadBalancerChildID(loadbalancerID string, names ...string) string {
+	return strings.Join(append([]string{loadbalancerID}, names...), "/")
+}
+
+// parseLoadBalancerChildID splits an ID created by loadBalancerChildID. format
+// describes the expected parts, e.g. "<loadbalancerid>/<backend>".
+func parseLoadBalancerChildID(id string, format string) ([]string, error) {
+	want := strings.Count(format, "/") + 1
+	s := strings.Split(id, "/")
+	if len(s) != want {
+		return nil, fmt.Errorf("invalid ID %q, expected %s", id, format)
+	}
+	for _, part := range s {
+		if part == "" {
+			return nil, fmt.Errorf("invalid ID %q, expected %s", id, format)
+		}
+	}
+	return s, nil
+}
+
+// upgradeLoadBalancerChildID rewrites the ID in a version 0 state, where the
+// name was used as ID, to the form created by loadBalancerChildID. keys are the
+// attributes making up the ID after the loadbalancer ID.
+func upgradeLoadBalancerChildID(rawState map[string]interface{}, keys ...string) (map[string]interface{}, error) {
+	if rawState == nil {
+		return rawState, nil
+	}
+
+	loadbalancerID, _ := rawState["loadbalancerid"].(string)
+	if loadbalancerID == "" {
+		return nil, fmt.Errorf("unable to upgrade state: loadbalancerid is missing")
+	}
+
+	var names []string
+	for _, key := range keys {
+		name, _ := rawState[key].(string)
+		if name == "" {
+			return nil, fmt.Errorf("unable to upgrade state: %s is missing", key)
+		}
+		names = append(names, name)
+	}
+
+	rawState["id"] = loadBalancerChildID(loadbalancerID, names...)
+	return rawState, nil
+}
@@ -2,6 +2,9 @@ package glesys
 
 import (
 	"context"
+	"fmt"
+	"log"
+	"time"
 
 	"github.com/glesys/glesys-go/v8"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -14,9 +17,27 @@ func resourceGlesysLoadBalancerBackend() *schema.Resource {
 		ReadContext:   resourceGlesysLoadBalancerBackendRead,
 		UpdateContext: resourceGlesysLoadBalancerBackendUpdate,
 		DeleteContext: resourceGlesysLoadBalancerBackendDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceGlesysLoadBalancerBackendImport,
+		},
 
 		Description: "LoadBalancer Backend for a glesys_loadbalancer",
 
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{
+			{
+				Type:    resourceGlesysLoadBalancerBackendV0().CoreConfigSchema().ImpliedType(),
+				Upgrade: resourceGlesysLoadBalancerBackendStateUpgradeV0,
+				Version: 0,
+			},
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+
 		Schema: map[string]*schema.Schema{
 			"connecttimeout": {
 				Description: "Connection timeout to backend target. `milliseconds`",
@@ -25,6 +46,12 @@ func resourceGlesysLoadBalancerBackend() *schema.Resource {
 				Optional:    true,
 			},
 
+			"id": {
+				Description: "Backend ID. `<loadbalancerid>/<name>`",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+
 			"loadbalancerid": {
 				Description: "LoadBalancer ID.",
 				Type:        schema.TypeString,
@@ -56,6 +83,7 @@ func resourceGlesysLoadBalancerBackend() *schema.Resource {
 				Description: "Enable backend sticky sessions. `true`, `false`, `yes`, `no`.",
 				Type:        schema.TypeString,
 				Optional:    true,
+				Computed:    true,
 			},
 
 			"status": {
@@ -74,9 +102,31 @@ func resourceGlesysLoadBalancerBackend() *schema.Resource {
 	}
 }
 
+// resourceGlesysLoadBalancerBackendImport - import backends "lb123456/backend"
+func resourceGlesysLoadBalancerBackendImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	s, err := parseLoadBalancerChildID(d.Id(), "<loadbalancerid>/<backend>")
+	if err != nil {
+		return nil, err
+	}
+
+	client := m.(*apiClient)
+	lb, err := client.LoadBalancers.Details(ctx, s[0])
+	if err != nil {
+		return nil, fmt.Errorf("error importing loadbalancer backend %s: %w", d.Id(), err)
+	}
+	if findLoadBalancerBackend(lb, s[1]) == nil {
+		return nil, fmt.Errorf("loadbalancer %s has no backend %q", s[0], s[1])
+	}
+
+	d.Set("loadbalancerid", s[0])
+	d.Set("name", s[1])
+
+	return []*schema.ResourceData{d}, nil
+}
+
 func resourceGlesysLoadBalancerBackendCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	// Add frontend to glesys_loadbalancer resource
-	client := m.(*glesys.Client)
+	client := m.(*apiClient)
 
 	params := glesys.AddBackendParams{
 		ConnectTimeout:  d.Get("connecttimeout").(int),
@@ -93,42 +143,57 @@ func resourceGlesysLoadBalancerBackendCreate(ctx context.Context, d *schema.Reso
 		return diag.Errorf("Error creating LoadBalancer Backend: %s", err)
 	}
 
-	d.SetId(d.Get("name").(string))
+	d.SetId(loadBalancerChildID(loadbalancerID, d.Get("name").(string)))
 
 	return resourceGlesysLoadBalancerBackendRead(ctx, d, m)
 }
 
 func resourceGlesysLoadBalancerBackendRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*glesys.Client)
+	client := m.(*apiClient)
 
 	loadbalancerid := d.Get("loadbalancerid").(string)
 	lb, err := client.LoadBalancers.Details(ctx, loadbalancerid)
 	if err != nil {
-		diag.Errorf("loadbalancer not found: %s", err)
+		return readError(d, err, "loadbalancer backend")
+	}
+
+	backend := findLoadBalancerBackend(lb, d.Get("name").(string))
+	if backend == nil {
+		log.Printf("[WARN] loadbalancer backend (%s) not found, removing from state", d.Id())
 		d.SetId("")
 		return nil
 	}
 
-	for _, n := range lb.BackendsList {
-		if n.Name == d.Get("name").(string) {
-			d.Set("mode", n.Mode)
-			d.Set("connecttimeout", n.ConnectTimeout)
-			d.Set("responsetimeout", n.ResponseTimeout)
-			d.Set("status", n.Status)
+	d.Set("loadbalancerid", loadbalancerid)
+	d.Set("name", backend.Name)
+	d.Set("mode", backend.Mode)
+	d.Set("connecttimeout", backend.ConnectTimeout)
+	d.Set("responsetimeout", backend.ResponseTimeout)
+	d.Set("stickysessions", backend.StickySession)
+	d.Set("status", backend.Status)
+
+	var targets []string
+	for _, t := range backend.Targets {
+		targets = append(targets, t.Name)
+	}
+	d.Set("targets", targets)
+
+	return nil
+}
 
-			var targets []string
-			for _, t := range n.Targets {
-				targets = append(targets, t.Name)
-			}
-			d.Set("targets", targets)
+// findLoadBalancerBackend returns the backend called name, or nil if lb has
+// no such backend.
+func findLoadBalancerBackend(lb *glesys.LoadBalancerDetails, name string) *glesys.LoadBalancerBackend {
+	for i := range lb.BackendsList {
+		if lb.BackendsList[i].Name == name {
+			return &lb.BackendsList[i]
 		}
 	}
-
 	return nil
 }
 
 func resourceGlesysLoadBalancerBackendUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*glesys.Client)
+	client := m.(*apiClient)
 
 	loadbalancerid := d.Get("loadbalancerid").(string)
 
@@ -165,7 +230,7 @@ func resourceGlesysLoadBalancerBackendUpdate(ctx context.Context, d *schema.Reso
 }
 
 func resourceGlesysLoadBalancerBackendDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*glesys.Client)
+	client := m.(*apiClient)
 
 	loadbalancerid := d.Get("loadbalancerid").(string)
 
@@ -181,3 +246,24 @@ func resourceGlesysLoadBalancerBackendDelete(ctx context.Context, d *schema.Reso
 	d.SetId("")
 	return nil
 }
+
+// resourceGlesysLoadBalancerBackendV0 is the schema of version 0, where the
+// backend name was used as ID.
+func resourceGlesysLoadBalancerBackendV0() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"connecttimeout":  {Type: schema.TypeInt, Optional: true, Computed: true},
+			"loadbalancerid":  {Type: schema.TypeString, Required: true},
+			"responsetimeout": {Type: schema.TypeInt, Optional: true, Computed: true},
+			"name":            {Type: schema.TypeString, Required: true},
+			"mode":            {Type: schema.TypeString, Optional: true},
+			"stickysessions":  {Type: schema.TypeString, Optional: true},
+			"status":          {Type: schema.TypeString, Computed: true},
+			"targets":         {Type: schema.TypeList, Computed: true, Elem: &schema.Schema{Type: schema.TypeString}},
+		},
+	}
+}
+
+func resourceGlesysLoadBalancerBackendStateUpgradeV0(ctx context.Context, rawState map[string]interface{}, m interface{}) (map[string]interface{}, error) {
+	return upgradeLoadBalancerChildID(rawState, "name")
+}
@@ -2,6 +2,9 @@ package glesys
 
 import (
 	"context"
+	"fmt"
+	"log"
+	"time"
 
 	"github.com/glesys/glesys-go/v8"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -14,9 +17,27 @@ func resourceGlesysLoadBalancerFrontend() *schema.Resource {
 		ReadContext:   resourceGlesysLoadBalancerFrontendRead,
 		UpdateContext: resourceGlesysLoadBalancerFrontendUpdate,
 		DeleteContext: resourceGlesysLoadBalancerFrontendDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceGlesysLoadBalancerFrontendImport,
+		},
 
 		Description: "Create a LoadBalancer Frontend for a `glesys_loadbalancer`.",
 
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{
+			{
+				Type:    resourceGlesysLoadBalancerFrontendV0().CoreConfigSchema().ImpliedType(),
+				Upgrade: resourceGlesysLoadBalancerFrontendStateUpgradeV0,
+				Version: 0,
+			},
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+
 		Schema: map[string]*schema.Schema{
 			"backend": {
 				Description: "LoadBalancer Backend name.",
@@ -32,6 +53,12 @@ func resourceGlesysLoadBalancerFrontend() *schema.Resource {
 				Optional:    true,
 			},
 
+			"id": {
+				Description: "Frontend ID. `<loadbalancerid>/<name>`",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+
 			"loadbalancerid": {
 				Description: "LoadBalancer to associate the Frontend to.",
 				Type:        schema.TypeString,
@@ -75,9 +102,31 @@ func resourceGlesysLoadBalancerFrontend() *schema.Resource {
 	}
 }
 
+// resourceGlesysLoadBalancerFrontendImport - import frontends "lb123456/frontend"
+func resourceGlesysLoadBalancerFrontendImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	s, err := parseLoadBalancerChildID(d.Id(), "<loadbalancerid>/<frontend>")
+	if err != nil {
+		return nil, err
+	}
+
+	client := m.(*apiClient)
+	lb, err := client.LoadBalancers.Details(ctx, s[0])
+	if err != nil {
+		return nil, fmt.Errorf("error importing loadbalancer frontend %s: %w", d.Id(), err)
+	}
+	if findLoadBalancerFrontend(lb, s[1]) == nil {
+		return nil, fmt.Errorf("loadbalancer %s has no frontend %q", s[0], s[1])
+	}
+
+	d.Set("loadbalancerid", s[0])
+	d.Set("name", s[1])
+
+	return []*schema.ResourceData{d}, nil
+}
+
 func resourceGlesysLoadBalancerFrontendCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	// Add frontend to glesys_loadbalancer resource
-	client := m.(*glesys.Client)
+	client := m.(*apiClient)
 
 	params := glesys.AddFrontendParams{
 		Backend:        d.Get("backend").(string),
@@ -95,38 +144,52 @@ func resourceGlesysLoadBalancerFrontendCreate(ctx context.Context, d *schema.Res
 		return diag.Errorf("Error creating LoadBalancer Frontend: %s", err)
 	}
 
-	d.SetId(d.Get("name").(string))
+	d.SetId(loadBalancerChildID(loadbalancerID, d.Get("name").(string)))
 
 	return resourceGlesysLoadBalancerFrontendRead(ctx, d, m)
 }
 
 func resourceGlesysLoadBalancerFrontendRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*glesys.Client)
+	client := m.(*apiClient)
 
 	loadbalancerid := d.Get("loadbalancerid").(string)
 	lb, err := client.LoadBalancers.Details(ctx, loadbalancerid)
 	if err != nil {
-		diag.Errorf("loadbalancer not found: %s", err)
+		return readError(d, err, "loadbalancer frontend")
+	}
+
+	frontend := findLoadBalancerFrontend(lb, d.Get("name").(string))
+	if frontend == nil {
+		log.Printf("[WARN] loadbalancer frontend (%s) not found, removing from state", d.Id())
 		d.SetId("")
 		return nil
 	}
 
-	for _, n := range lb.FrontendsList {
-		if n.Name == d.Get("name").(string) {
-			d.Set("backend", n.Backend)
-			d.Set("clienttimeout", n.ClientTimeout)
-			d.Set("maxconnections", n.MaxConnections)
-			d.Set("port", n.Port)
-			d.Set("sslcertificate", n.SSLCertificate)
-			d.Set("status", n.Status)
+	d.Set("loadbalancerid", loadbalancerid)
+	d.Set("name", frontend.Name)
+	d.Set("backend", frontend.Backend)
+	d.Set("clienttimeout", frontend.ClientTimeout)
+	d.Set("maxconnections", frontend.MaxConnections)
+	d.Set("port", frontend.Port)
+	d.Set("sslcertificate", frontend.SSLCertificate)
+	d.Set("status", frontend.Status)
+
+	return nil
+}
+
+// findLoadBalancerFrontend returns the frontend called name, or nil if lb has
+// no such frontend.
+func findLoadBalancerFrontend(lb *glesys.LoadBalancerDetails, name string) *glesys.LoadBalancerFrontend {
+	for i := range lb.FrontendsList {
+		if lb.FrontendsList[i].Name == name {
+			return &lb.FrontendsList[i]
 		}
 	}
-
 	return nil
 }
 
 func resourceGlesysLoadBalancerFrontendUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*glesys.Client)
+	client := m.(*apiClient)
 
 	loadbalancerid := d.Get("loadbalancerid").(string)
 
@@ -163,7 +226,7 @@ func resourceGlesysLoadBalancerFrontendUpdate(ctx context.Context, d *schema.Res
 }
 
 func resourceGlesysLoadBalancerFrontendDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*glesys.Client)
+	client := m.(*apiClient)
 
 	loadbalancerid := d.Get("loadbalancerid").(string)
 
@@ -179,3 +242,24 @@ func resourceGlesysLoadBalancerFrontendDelete(ctx context.Context, d *schema.Res
 	d.SetId("")
 	return nil
 }
+
+// resourceGlesysLoadBalancerFrontendV0 is the schema of version 0, where the
+// frontend name was used as ID.
+func resourceGlesysLoadBalancerFrontendV0() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"backend":        {Type: schema.TypeString, Required: true},
+			"clienttimeout":  {Type: schema.TypeInt, Optional: true, Computed: true},
+			"loadbalancerid": {Type: schema.TypeString, Required: true},
+			"maxconnections": {Type: schema.TypeInt, Optional: true, Computed: true},
+			"name":           {Type: schema.TypeString, Required: true},
+			"port":           {Type: schema.TypeInt, Required: true},
+			"sslcertificate": {Type: schema.TypeString, Optional: true},
+			"status":         {Type: schema.TypeString, Computed: true},
+		},
+	}
+}
+
+func resourceGlesysLoadBalancerFrontendStateUpgradeV0(ctx context.Context, rawState map[string]interface{}, m interface{}) (map[string]interface{}, error) {
+	return upgradeLoadBalancerChildID(rawState, "name")
+}
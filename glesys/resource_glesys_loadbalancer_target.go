@@ -2,6 +2,9 @@ package glesys
 
 import (
 	"context"
+	"fmt"
+	"log"
+	"time"
 
 	"github.com/glesys/glesys-go/v8"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -14,9 +17,27 @@ func resourceGlesysLoadBalancerTarget() *schema.Resource {
 		ReadContext:   resourceGlesysLoadBalancerTargetRead,
 		UpdateContext: resourceGlesysLoadBalancerTargetUpdate,
 		DeleteContext: resourceGlesysLoadBalancerTargetDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceGlesysLoadBalancerTargetImport,
+		},
 
 		Description: "Create a LoadBalancer Target for a `glesys_loadbalancer_backend`.",
 
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{
+			{
+				Type:    resourceGlesysLoadBalancerTargetV0().CoreConfigSchema().ImpliedType(),
+				Upgrade: resourceGlesysLoadBalancerTargetStateUpgradeV0,
+				Version: 0,
+			},
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+
 		Schema: map[string]*schema.Schema{
 			"backend": {
 				Description: "Backend to associate with.",
@@ -32,6 +53,12 @@ func resourceGlesysLoadBalancerTarget() *schema.Resource {
 				Optional:    true,
 			},
 
+			"id": {
+				Description: "Target ID. `<loadbalancerid>/<backend>/<name>`",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+
 			"loadbalancerid": {
 				Description: "LoadBalancer ID.",
 				Type:        schema.TypeString,
@@ -73,9 +100,32 @@ func resourceGlesysLoadBalancerTarget() *schema.Resource {
 	}
 }
 
+// resourceGlesysLoadBalancerTargetImport - import targets "lb123456/backend/target"
+func resourceGlesysLoadBalancerTargetImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	s, err := parseLoadBalancerChildID(d.Id(), "<loadbalancerid>/<backend>/<target>")
+	if err != nil {
+		return nil, err
+	}
+
+	client := m.(*apiClient)
+	lb, err := client.LoadBalancers.Details(ctx, s[0])
+	if err != nil {
+		return nil, fmt.Errorf("error importing loadbalancer target %s: %w", d.Id(), err)
+	}
+	if findLoadBalancerTarget(lb, s[1], s[2]) == nil {
+		return nil, fmt.Errorf("loadbalancer %s has no target %q in backend %q", s[0], s[2], s[1])
+	}
+
+	d.Set("loadbalancerid", s[0])
+	d.Set("backend", s[1])
+	d.Set("name", s[2])
+
+	return []*schema.ResourceData{d}, nil
+}
+
 func resourceGlesysLoadBalancerTargetCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	// Add target to glesys_loadbalancer_backend resource
-	client := m.(*glesys.Client)
+	client := m.(*apiClient)
 
 	params := glesys.AddTargetParams{
 		Backend:  d.Get("backend").(string),
@@ -102,46 +152,55 @@ func resourceGlesysLoadBalancerTargetCreate(ctx context.Context, d *schema.Resou
 		}
 	}
 
-	d.SetId(d.Get("name").(string))
+	d.SetId(loadBalancerChildID(loadbalancerID, d.Get("backend").(string), d.Get("name").(string)))
 
 	return resourceGlesysLoadBalancerTargetRead(ctx, d, m)
 }
 
 func resourceGlesysLoadBalancerTargetRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*glesys.Client)
+	client := m.(*apiClient)
 
 	loadbalancerid := d.Get("loadbalancerid").(string)
 	lb, err := client.LoadBalancers.Details(ctx, loadbalancerid)
 	if err != nil {
-		diag.Errorf("loadbalancer not found: %s", err)
+		return readError(d, err, "loadbalancer target")
+	}
+
+	target := findLoadBalancerTarget(lb, d.Get("backend").(string), d.Get("name").(string))
+	if target == nil {
+		log.Printf("[WARN] loadbalancer target (%s) not found, removing from state", d.Id())
 		d.SetId("")
 		return nil
 	}
 
-	// iterate over all backends && targets of the loadbalancer
-	for _, n := range lb.BackendsList {
-		if n.Name == d.Get("backend").(string) {
-			for _, t := range n.Targets {
-				if t.Name == d.Get("name").(string) {
-					d.Set("enabled", t.Enabled)
-					d.Set("port", t.Port)
-					d.Set("status", t.Status)
-					d.Set("targetip", t.TargetIP)
-					d.Set("weight", t.Weight)
-				}
-			}
-		} else {
-			diag.Errorf("loadbalancer Target not found: %s", d.Get("name").(string))
-			d.SetId("")
-			return nil
+	d.Set("loadbalancerid", loadbalancerid)
+	d.Set("name", target.Name)
+	d.Set("enabled", target.Enabled)
+	d.Set("port", target.Port)
+	d.Set("status", target.Status)
+	d.Set("targetip", target.TargetIP)
+	d.Set("weight", target.Weight)
+
+	return nil
+}
+
+// findLoadBalancerTarget returns the target called name in backend, or nil if
+// lb has no such target.
+func findLoadBalancerTarget(lb *glesys.LoadBalancerDetails, backend string, name string) *glesys.Target {
+	b := findLoadBalancerBackend(lb, backend)
+	if b == nil {
+		return nil
+	}
+	for i := range b.Targets {
+		if b.Targets[i].Name == name {
+			return &b.Targets[i]
 		}
 	}
-
 	return nil
 }
 
 func resourceGlesysLoadBalancerTargetUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*glesys.Client)
+	client := m.(*apiClient)
 
 	loadbalancerid := d.Get("loadbalancerid").(string)
 
@@ -198,7 +257,7 @@ func resourceGlesysLoadBalancerTargetUpdate(ctx context.Context, d *schema.Resou
 }
 
 func resourceGlesysLoadBalancerTargetDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*glesys.Client)
+	client := m.(*apiClient)
 
 	loadbalancerid := d.Get("loadbalancerid").(string)
 
@@ -215,3 +274,24 @@ func resourceGlesysLoadBalancerTargetDelete(ctx context.Context, d *schema.Resou
 	d.SetId("")
 	return nil
 }
+
+// resourceGlesysLoadBalancerTargetV0 is the schema of version 0, where the
+// target name was used as ID.
+func resourceGlesysLoadBalancerTargetV0() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"backend":        {Type: schema.TypeString, Required: true},
+			"enabled":        {Type: schema.TypeBool, Optional: true, Computed: true},
+			"loadbalancerid": {Type: schema.TypeString, Required: true},
+			"name":           {Type: schema.TypeString, Required: true},
+			"port":           {Type: schema.TypeInt, Required: true},
+			"status":         {Type: schema.TypeString, Computed: true},
+			"targetip":       {Type: schema.TypeString, Required: true},
+			"weight":         {Type: schema.TypeInt, Required: true},
+		},
+	}
+}
+
+func resourceGlesysLoadBalancerTargetStateUpgradeV0(ctx context.Context, rawState map[string]interface{}, m interface{}) (map[string]interface{}, error) {
+	return upgradeLoadBalancerChildID(rawState, "backend", "name")
+}
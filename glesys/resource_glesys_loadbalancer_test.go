@@ -0,0 +1,283 @@
+package glesys
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/glesys/glesys-go/v8"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestAccGlesysLoadBalancer_basic(t *testing.T) {
+	rName := acctest.RandomWithPrefix("tf-lb")
+
+	lbName := "glesys_loadbalancer.test"
+	backendName := "glesys_loadbalancer_backend.test"
+	frontendName := "glesys_loadbalancer_frontend.test"
+	targetName := "glesys_loadbalancer_target.test"
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testGlesysProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccGlesysLoadBalancer(rName, 5, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(lbName, "name", rName),
+					resource.TestCheckResourceAttr(lbName, "datacenter", "Falkenberg"),
+					resource.TestCheckResourceAttr(lbName, "iplist.#", "1"),
+					resource.TestCheckResourceAttr(backendName, "name", "tf-backend"),
+					resource.TestCheckResourceAttr(backendName, "mode", "http"),
+					resource.TestCheckResourceAttrPair(backendName, "loadbalancerid", lbName, "id"),
+					resource.TestMatchResourceAttr(backendName, "id", regexp.MustCompile(`^lb[^/]+/tf-backend$`)),
+					resource.TestMatchResourceAttr(frontendName, "id", regexp.MustCompile(`^lb[^/]+/tf-frontend$`)),
+					resource.TestMatchResourceAttr(targetName, "id", regexp.MustCompile(`^lb[^/]+/tf-backend/tf-target$`)),
+					resource.TestCheckResourceAttr(frontendName, "port", "80"),
+					resource.TestCheckResourceAttr(frontendName, "backend", "tf-backend"),
+					resource.TestCheckResourceAttr(targetName, "targetip", "203.0.113.10"),
+					resource.TestCheckResourceAttr(targetName, "weight", "5"),
+					resource.TestCheckResourceAttr(targetName, "enabled", "true"),
+				),
+			},
+			{
+				ResourceName:      lbName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      backendName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      frontendName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      targetName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccGlesysLoadBalancer(rName+"-renamed", 10, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(lbName, "name", rName+"-renamed"),
+					resource.TestCheckResourceAttr(targetName, "weight", "10"),
+					resource.TestCheckResourceAttr(targetName, "enabled", "false"),
+				),
+			},
+		},
+	})
+}
+
+func testAccGlesysLoadBalancer(name string, weight int, enabled bool) string {
+	return fmt.Sprintf(`
+		resource "glesys_loadbalancer" "test" {
+			name       = "%s"
+			datacenter = "Falkenberg"
+		}
+
+		resource "glesys_loadbalancer_backend" "test" {
+			loadbalancerid = glesys_loadbalancer.test.id
+			name           = "tf-backend"
+			mode           = "http"
+		}
+
+		resource "glesys_loadbalancer_frontend" "test" {
+			loadbalancerid = glesys_loadbalancer.test.id
+			name           = "tf-frontend"
+			backend        = glesys_loadbalancer_backend.test.name
+			port           = 80
+		}
+
+		resource "glesys_loadbalancer_target" "test" {
+			loadbalancerid = glesys_loadbalancer.test.id
+			backend        = glesys_loadbalancer_backend.test.name
+			name           = "tf-target"
+			targetip       = "203.0.113.10"
+			port           = 8080
+			weight         = %d
+			enabled        = %t
+		} `, name, weight, enabled)
+}
+
+func TestResourceGlesysLoadBalancerStateUpgradeV0(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		upgrade func(context.Context, map[string]interface{}, interface{}) (map[string]interface{}, error)
+		state   map[string]interface{}
+		wantID  string
+		wantErr bool
+	}{
+		{
+			name:    "backend",
+			upgrade: resourceGlesysLoadBalancerBackendStateUpgradeV0,
+			state:   map[string]interface{}{"id": "web", "loadbalancerid": "lb123456", "name": "web"},
+			wantID:  "lb123456/web",
+		},
+		{
+			name:    "frontend",
+			upgrade: resourceGlesysLoadBalancerFrontendStateUpgradeV0,
+			state:   map[string]interface{}{"id": "http", "loadbalancerid": "lb123456", "name": "http", "backend": "web"},
+			wantID:  "lb123456/http",
+		},
+		{
+			name:    "target",
+			upgrade: resourceGlesysLoadBalancerTargetStateUpgradeV0,
+			state:   map[string]interface{}{"id": "web1", "loadbalancerid": "lb123456", "name": "web1", "backend": "web"},
+			wantID:  "lb123456/web/web1",
+		},
+		{
+			name:    "missing_loadbalancerid",
+			upgrade: resourceGlesysLoadBalancerBackendStateUpgradeV0,
+			state:   map[string]interface{}{"id": "web", "name": "web"},
+			wantErr: true,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.upgrade(context.Background(), tt.state, nil)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("got error: %v, want error %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			want := map[string]interface{}{}
+			for k, v := range tt.state {
+				want[k] = v
+			}
+			want["id"] = tt.wantID
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("got: %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func Test_parseLoadBalancerChildID(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		id      string
+		format  string
+		want    []string
+		wantErr bool
+	}{
+		{name: "backend", id: "lb123456/web", format: "<loadbalancerid>/<backend>", want: []string{"lb123456", "web"}},
+		{name: "target", id: "lb123456/web/web1", format: "<loadbalancerid>/<backend>/<target>", want: []string{"lb123456", "web", "web1"}},
+		{name: "name_only", id: "web", format: "<loadbalancerid>/<backend>", wantErr: true},
+		{name: "too_many_parts", id: "lb123456/web/web1", format: "<loadbalancerid>/<backend>", wantErr: true},
+		{name: "empty_part", id: "lb123456//web1", format: "<loadbalancerid>/<backend>/<target>", wantErr: true},
+		{name: "comma_separated", id: "lb123456,web", format: "<loadbalancerid>/<backend>", wantErr: true},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseLoadBalancerChildID(tt.id, tt.format)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("got error: %v, want error %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got: %v, want %v", got, tt.want)
+			}
+			if !tt.wantErr && loadBalancerChildID(got[0], got[1:]...) != tt.id {
+				t.Errorf("loadBalancerChildID(%v) = %q, want %q", got, loadBalancerChildID(got[0], got[1:]...), tt.id)
+			}
+		})
+	}
+}
+
+func TestResourceGlesysLoadBalancerChildImport(t *testing.T) {
+	_, client := newFakeClient(t)
+	ctx := context.Background()
+
+	lb, err := client.LoadBalancers.Create(ctx, glesys.CreateLoadBalancerParams{DataCenter: "Falkenberg", Name: "tf-lb"})
+	if err != nil {
+		t.Fatalf("could not create loadbalancer: %s", err)
+	}
+	if _, err := client.LoadBalancers.AddBackend(ctx, lb.ID, glesys.AddBackendParams{Name: "web", Mode: "http"}); err != nil {
+		t.Fatalf("could not create backend: %s", err)
+	}
+	if _, err := client.LoadBalancers.AddFrontend(ctx, lb.ID, glesys.AddFrontendParams{Name: "http", Backend: "web", Port: 80}); err != nil {
+		t.Fatalf("could not create frontend: %s", err)
+	}
+	if _, err := client.LoadBalancers.AddTarget(ctx, lb.ID, glesys.AddTargetParams{Backend: "web", Name: "web1", Port: 8080, TargetIP: "203.0.113.10", Weight: 5}); err != nil {
+		t.Fatalf("could not create target: %s", err)
+	}
+
+	for _, tt := range []struct {
+		name     string
+		resource *schema.Resource
+		id       string
+		want     map[string]string
+		wantErr  bool
+	}{
+		{
+			name:     "backend",
+			resource: resourceGlesysLoadBalancerBackend(),
+			id:       lb.ID + "/web",
+			want:     map[string]string{"loadbalancerid": lb.ID, "name": "web"},
+		},
+		{
+			name:     "frontend",
+			resource: resourceGlesysLoadBalancerFrontend(),
+			id:       lb.ID + "/http",
+			want:     map[string]string{"loadbalancerid": lb.ID, "name": "http"},
+		},
+		{
+			name:     "target",
+			resource: resourceGlesysLoadBalancerTarget(),
+			id:       lb.ID + "/web/web1",
+			want:     map[string]string{"loadbalancerid": lb.ID, "backend": "web", "name": "web1"},
+		},
+		{
+			name:     "backend_name_only",
+			resource: resourceGlesysLoadBalancerBackend(),
+			id:       "web",
+			wantErr:  true,
+		},
+		{
+			name:     "missing_backend",
+			resource: resourceGlesysLoadBalancerBackend(),
+			id:       lb.ID + "/api",
+			wantErr:  true,
+		},
+		{
+			name:     "missing_target",
+			resource: resourceGlesysLoadBalancerTarget(),
+			id:       lb.ID + "/web/web2",
+			wantErr:  true,
+		},
+		{
+			name:     "missing_loadbalancer",
+			resource: resourceGlesysLoadBalancerFrontend(),
+			id:       "lb404/http",
+			wantErr:  true,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			d := tt.resource.TestResourceData()
+			d.SetId(tt.id)
+
+			got, err := tt.resource.Importer.StateContext(ctx, d, client)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("got error: %v, want error %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if len(got) != 1 || got[0].Id() != tt.id {
+				t.Fatalf("expected the ID %q to be kept, got %v", tt.id, got)
+			}
+			for k, v := range tt.want {
+				if got := got[0].Get(k).(string); got != v {
+					t.Errorf("%s: got %q, want %q", k, got, v)
+				}
+			}
+		})
+	}
+}
@@ -14,6 +14,9 @@ func resourceGlesysNetwork() *schema.Resource {
 		ReadContext:   resourceGlesysNetworkRead,
 		UpdateContext: resourceGlesysNetworkUpdate,
 		DeleteContext: resourceGlesysNetworkDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
 
 		Description: "Create a private network in the VMware environment.",
 
@@ -38,7 +41,7 @@ func resourceGlesysNetwork() *schema.Resource {
 }
 
 func resourceGlesysNetworkCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*glesys.Client)
+	client := m.(*apiClient)
 
 	params := glesys.CreateNetworkParams{
 		DataCenter:  d.Get("datacenter").(string),
@@ -56,13 +59,11 @@ func resourceGlesysNetworkCreate(ctx context.Context, d *schema.ResourceData, m
 }
 
 func resourceGlesysNetworkRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*glesys.Client)
+	client := m.(*apiClient)
 
 	network, err := client.Networks.Details(ctx, d.Id())
 	if err != nil {
-		diag.Errorf("network not found: %s", err)
-		d.SetId("")
-		return nil
+		return readError(d, err, "network")
 	}
 
 	d.Set("datacenter", network.DataCenter)
@@ -73,7 +74,7 @@ func resourceGlesysNetworkRead(ctx context.Context, d *schema.ResourceData, m in
 }
 
 func resourceGlesysNetworkUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*glesys.Client)
+	client := m.(*apiClient)
 
 	params := glesys.EditNetworkParams{}
 
@@ -89,7 +90,7 @@ func resourceGlesysNetworkUpdate(ctx context.Context, d *schema.ResourceData, m
 }
 
 func resourceGlesysNetworkDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*glesys.Client)
+	client := m.(*apiClient)
 
 	// TODO: check if network is used before deletion.
 	// remove networkadapter, then network
@@ -24,6 +24,11 @@ func TestAccGlesysNetwork_basic(t *testing.T) {
 					resource.TestCheckResourceAttr(name, "public", "no"),
 				),
 			},
+			{
+				ResourceName:      name,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
 		},
 	})
 }
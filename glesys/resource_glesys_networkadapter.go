@@ -2,6 +2,7 @@ package glesys
 
 import (
 	"context"
+	"time"
 
 	"github.com/glesys/glesys-go/v8"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -20,6 +21,12 @@ func resourceGlesysNetworkAdapter() *schema.Resource {
 
 		Description: "Create a networkadapter attached to a VMware server.",
 
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+
 		Schema: map[string]*schema.Schema{
 			"adaptertype": {
 				Description: "`VMXNET 3` (default) or `E1000`",
@@ -55,7 +62,7 @@ func resourceGlesysNetworkAdapter() *schema.Resource {
 }
 
 func resourceGlesysNetworkAdapterCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*glesys.Client)
+	client := m.(*apiClient)
 
 	params := glesys.CreateNetworkAdapterParams{
 		AdapterType: d.Get("adaptertype").(string),
@@ -65,8 +72,11 @@ func resourceGlesysNetworkAdapterCreate(ctx context.Context, d *schema.ResourceD
 		ServerID:    d.Get("serverid").(string),
 	}
 
+	serverMutexKV.Lock(params.ServerID)
+	defer serverMutexKV.Unlock(params.ServerID)
+
 	// Wait for server: ServerID to be unlocked before creating adapters
-	if _, err := waitForServerLocked(ctx, params.ServerID, "false", []string{"true"}, "islocked", m); err != nil {
+	if _, err := waitForServerLocked(ctx, params.ServerID, "false", []string{"true"}, "islocked", d.Timeout(schema.TimeoutCreate), m); err != nil {
 		return diag.Errorf("networkadapter: error while waiting for Server (%s) to be completed: %s", params.ServerID, err)
 	}
 
@@ -80,13 +90,11 @@ func resourceGlesysNetworkAdapterCreate(ctx context.Context, d *schema.ResourceD
 }
 
 func resourceGlesysNetworkAdapterRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*glesys.Client)
+	client := m.(*apiClient)
 
 	networkadapter, err := client.NetworkAdapters.Details(ctx, d.Id())
 	if err != nil {
-		diag.Errorf("adapter not found: %s", err)
-		d.SetId("")
-		return nil
+		return readError(d, err, "network adapter")
 	}
 
 	d.Set("adaptertype", networkadapter.AdapterType)
@@ -99,7 +107,7 @@ func resourceGlesysNetworkAdapterRead(ctx context.Context, d *schema.ResourceDat
 }
 
 func resourceGlesysNetworkAdapterUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*glesys.Client)
+	client := m.(*apiClient)
 
 	params := glesys.EditNetworkAdapterParams{}
 
@@ -116,6 +124,14 @@ func resourceGlesysNetworkAdapterUpdate(ctx context.Context, d *schema.ResourceD
 		params.NetworkID = d.Get("networkid").(string)
 	}
 
+	serverID := d.Get("serverid").(string)
+	serverMutexKV.Lock(serverID)
+	defer serverMutexKV.Unlock(serverID)
+
+	if _, err := waitForServerLocked(ctx, serverID, "false", []string{"true"}, "islocked", d.Timeout(schema.TimeoutUpdate), m); err != nil {
+		return diag.Errorf("networkadapter: error while waiting for Server (%s) to be unlocked: %s", serverID, err)
+	}
+
 	_, err := client.NetworkAdapters.Edit(ctx, d.Id(), params)
 	if err != nil {
 		return diag.Errorf("Error updating adapter: %s", err)
@@ -124,7 +140,15 @@ func resourceGlesysNetworkAdapterUpdate(ctx context.Context, d *schema.ResourceD
 }
 
 func resourceGlesysNetworkAdapterDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*glesys.Client)
+	client := m.(*apiClient)
+
+	serverID := d.Get("serverid").(string)
+	serverMutexKV.Lock(serverID)
+	defer serverMutexKV.Unlock(serverID)
+
+	if _, err := waitForServerLocked(ctx, serverID, "false", []string{"true"}, "islocked", d.Timeout(schema.TimeoutDelete), m); err != nil {
+		return diag.Errorf("networkadapter: error while waiting for Server (%s) to be unlocked: %s", serverID, err)
+	}
 
 	err := client.NetworkAdapters.Destroy(ctx, d.Id())
 	if err != nil {
@@ -0,0 +1,48 @@
+package glesys
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccGlesysNetworkAdapter_basic(t *testing.T) {
+	sName := randomTestName("adapter")
+
+	name := "glesys_networkadapter.test"
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testGlesysProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccGlesysServerBaseVMware(sName) + testAccGlesysNetworkAdapter(100),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(name, "serverid", "glesys_server.test", "id"),
+					resource.TestCheckResourceAttr(name, "bandwidth", "100"),
+					resource.TestCheckResourceAttrSet(name, "networkid"),
+					resource.TestCheckResourceAttrSet(name, "adaptertype"),
+				),
+			},
+			{
+				Config: testAccGlesysServerBaseVMware(sName) + testAccGlesysNetworkAdapter(200),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "bandwidth", "200"),
+				),
+			},
+			{
+				ResourceName:      name,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccGlesysNetworkAdapter(bandwidth int) string {
+	return fmt.Sprintf(`
+		resource "glesys_networkadapter" "test" {
+			serverid  = glesys_server.test.id
+			bandwidth = %d
+		} `, bandwidth)
+}
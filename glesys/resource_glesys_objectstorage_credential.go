@@ -2,6 +2,9 @@ package glesys
 
 import (
 	"context"
+	"fmt"
+	"log"
+	"strings"
 
 	"github.com/glesys/glesys-go/v8"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -13,6 +16,9 @@ func resourceGlesysObjectStorageCredential() *schema.Resource {
 		CreateContext: resourceGlesysObjectStorageCredentialCreate,
 		ReadContext:   resourceGlesysObjectStorageCredentialRead,
 		DeleteContext: resourceGlesysObjectStorageCredentialDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceGlesysObjectStorageCredentialImport,
+		},
 
 		Description: "ObjectStorage Credentials.",
 		Schema: map[string]*schema.Schema{
@@ -48,8 +54,32 @@ func resourceGlesysObjectStorageCredential() *schema.Resource {
 	}
 }
 
+// resourceGlesysObjectStorageCredentialImport - import credentials "<instanceid>,<credentialid>"
+// The secret key is only returned when the credential is created, and can't be imported.
+func resourceGlesysObjectStorageCredentialImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	s := strings.Split(d.Id(), ",")
+	if len(s) != 2 || s[0] == "" || s[1] == "" {
+		return nil, fmt.Errorf("not enough parameters ( <instanceid>,<credentialid> ) : %s", d.Id())
+	}
+
+	client := m.(*apiClient)
+	instance, err := client.ObjectStorages.InstanceDetails(ctx, s[0])
+	if err != nil {
+		return nil, fmt.Errorf("error importing object storage credential %s: %w", d.Id(), err)
+	}
+
+	if findObjectStorageCredential(instance, s[1]) == nil {
+		return nil, fmt.Errorf("object storage instance %s has no credential %s", s[0], s[1])
+	}
+
+	d.SetId(s[1])
+	d.Set("instanceid", s[0])
+
+	return []*schema.ResourceData{d}, nil
+}
+
 func resourceGlesysObjectStorageCredentialCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*glesys.Client)
+	client := m.(*apiClient)
 
 	params := glesys.CreateObjectStorageCredentialParams{
 		InstanceID:  d.Get("instanceid").(string),
@@ -71,11 +101,41 @@ func resourceGlesysObjectStorageCredentialCreate(ctx context.Context, d *schema.
 }
 
 func resourceGlesysObjectStorageCredentialRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*apiClient)
+
+	instance, err := client.ObjectStorages.InstanceDetails(ctx, d.Get("instanceid").(string))
+	if err != nil {
+		return readError(d, err, "object storage credential")
+	}
+
+	credential := findObjectStorageCredential(instance, d.Id())
+	if credential == nil {
+		log.Printf("[WARN] object storage credential (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	// The secret key is only returned on create, keep the one in state.
+	d.Set("accesskey", credential.AccessKey)
+	d.Set("description", credential.Description)
+	d.Set("created", credential.Created)
+
+	return nil
+}
+
+// findObjectStorageCredential returns the credential with id, or nil if the
+// instance has no such credential.
+func findObjectStorageCredential(instance *glesys.ObjectStorageInstance, id string) *glesys.ObjectStorageCredential {
+	for i := range instance.Credentials {
+		if instance.Credentials[i].CredentialID == id {
+			return &instance.Credentials[i]
+		}
+	}
 	return nil
 }
 
 func resourceGlesysObjectStorageCredentialDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*glesys.Client)
+	client := m.(*apiClient)
 
 	params := glesys.DeleteObjectStorageCredentialParams{
 		InstanceID:   d.Get("instanceid").(string),
@@ -83,7 +143,7 @@ func resourceGlesysObjectStorageCredentialDelete(ctx context.Context, d *schema.
 	}
 
 	err := client.ObjectStorages.DeleteCredential(ctx, params)
-	if err != nil {
+	if err != nil && !isNotFoundError(err) {
 		return diag.Errorf("Error deleting object storage credential: %s", err)
 	}
 
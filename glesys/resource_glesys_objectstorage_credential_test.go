@@ -1,10 +1,13 @@
 package glesys
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
+	"github.com/glesys/glesys-go/v8"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 func TestAccObjectStorageCredential_basic(t *testing.T) {
@@ -29,6 +32,14 @@ func TestAccObjectStorageCredential_basic(t *testing.T) {
 					resource.TestCheckResourceAttr(name, "description", "tf-test"),
 				),
 			},
+			{
+				ResourceName:      name,
+				ImportState:       true,
+				ImportStateIdFunc: testAccObjectStorageCredentialImportID(name),
+				ImportStateVerify: true,
+				// The secret key is only returned when the credential is created.
+				ImportStateVerifyIgnore: []string{"secretkey"},
+			},
 		},
 	})
 }
@@ -44,3 +55,140 @@ func glesysObjectStorageSkeleton(s string) string {
 			%s
 		}`, s)
 }
+
+func testAccObjectStorageCredentialImportID(name string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return "", fmt.Errorf("not found: %s", name)
+		}
+		return fmt.Sprintf("%s,%s", rs.Primary.Attributes["instanceid"], rs.Primary.ID), nil
+	}
+}
+
+func TestResourceGlesysObjectStorageCredentialImport(t *testing.T) {
+	_, client := newFakeClient(t)
+	ctx := context.Background()
+
+	instance, err := client.ObjectStorages.CreateInstance(ctx, glesys.CreateObjectStorageInstanceParams{DataCenter: "dc-sto1"})
+	if err != nil {
+		t.Fatalf("could not create instance: %s", err)
+	}
+	credential, err := client.ObjectStorages.CreateCredential(ctx, glesys.CreateObjectStorageCredentialParams{
+		InstanceID:  instance.InstanceID,
+		Description: "tf-test",
+	})
+	if err != nil {
+		t.Fatalf("could not create credential: %s", err)
+	}
+
+	for _, tt := range []struct {
+		name    string
+		id      string
+		wantErr bool
+	}{
+		{name: "valid", id: instance.InstanceID + "," + credential.CredentialID},
+		{name: "credential_only", id: credential.CredentialID, wantErr: true},
+		{name: "empty_instance", id: "," + credential.CredentialID, wantErr: true},
+		{name: "too_many_parts", id: instance.InstanceID + "," + credential.CredentialID + ",x", wantErr: true},
+		{name: "missing_credential", id: instance.InstanceID + ",cred-404", wantErr: true},
+		{name: "missing_instance", id: "os-404," + credential.CredentialID, wantErr: true},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			r := resourceGlesysObjectStorageCredential()
+			d := r.TestResourceData()
+			d.SetId(tt.id)
+
+			got, err := r.Importer.StateContext(ctx, d, client)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("got error: %v, want error %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			d = got[0]
+			if diags := resourceGlesysObjectStorageCredentialRead(ctx, d, client); diags.HasError() {
+				t.Fatalf("read failed: %s", diagnosticsToString(diags))
+			}
+			if d.Id() != credential.CredentialID {
+				t.Errorf("id: got %q, want %q", d.Id(), credential.CredentialID)
+			}
+			for k, want := range map[string]string{
+				"instanceid":  instance.InstanceID,
+				"accesskey":   credential.AccessKey,
+				"description": "tf-test",
+				"created":     credential.Created,
+				"secretkey":   "",
+			} {
+				if got := d.Get(k).(string); got != want {
+					t.Errorf("%s: got %q, want %q", k, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestResourceGlesysObjectStorageCredentialRead(t *testing.T) {
+	_, client := newFakeClient(t)
+	ctx := context.Background()
+
+	instance, err := client.ObjectStorages.CreateInstance(ctx, glesys.CreateObjectStorageInstanceParams{DataCenter: "dc-sto1"})
+	if err != nil {
+		t.Fatalf("could not create instance: %s", err)
+	}
+
+	r := resourceGlesysObjectStorageCredential()
+	d := r.TestResourceData()
+	d.Set("instanceid", instance.InstanceID)
+	d.Set("description", "tf-test")
+	if diags := r.CreateContext(ctx, d, client); diags.HasError() {
+		t.Fatalf("create failed: %s", diagnosticsToString(diags))
+	}
+	secretKey := d.Get("secretkey").(string)
+	if secretKey == "" {
+		t.Fatal("expected secretkey to be set on create")
+	}
+
+	if diags := r.ReadContext(ctx, d, client); diags.HasError() {
+		t.Fatalf("read failed: %s", diagnosticsToString(diags))
+	}
+	if got := d.Get("secretkey").(string); got != secretKey {
+		t.Errorf("secretkey: got %q, want it kept as %q", got, secretKey)
+	}
+	if got := d.Get("description").(string); got != "tf-test" {
+		t.Errorf("description: got %q, want %q", got, "tf-test")
+	}
+	if d.Get("created").(string) == "" {
+		t.Error("expected created to be set")
+	}
+
+	// Deleted outside of Terraform.
+	err = client.ObjectStorages.DeleteCredential(ctx, glesys.DeleteObjectStorageCredentialParams{
+		InstanceID:   instance.InstanceID,
+		CredentialID: d.Id(),
+	})
+	if err != nil {
+		t.Fatalf("could not delete credential: %s", err)
+	}
+	if diags := r.ReadContext(ctx, d, client); diags.HasError() {
+		t.Fatalf("read failed: %s", diagnosticsToString(diags))
+	}
+	if d.Id() != "" {
+		t.Errorf("expected deleted credential to be removed from state, got id %q", d.Id())
+	}
+
+	// The whole instance deleted outside of Terraform.
+	d = r.TestResourceData()
+	d.SetId("cred-1")
+	d.Set("instanceid", instance.InstanceID)
+	if err := client.ObjectStorages.DeleteInstance(ctx, instance.InstanceID); err != nil {
+		t.Fatalf("could not delete instance: %s", err)
+	}
+	if diags := r.ReadContext(ctx, d, client); diags.HasError() {
+		t.Fatalf("read failed: %s", diagnosticsToString(diags))
+	}
+	if d.Id() != "" {
+		t.Errorf("expected credential of deleted instance to be removed from state, got id %q", d.Id())
+	}
+}
@@ -52,7 +52,7 @@ func resourceGlesysObjectStorageInstance() *schema.Resource {
 }
 
 func resourceGlesysObjectStorageInstanceCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*glesys.Client)
+	client := m.(*apiClient)
 
 	params := glesys.CreateObjectStorageInstanceParams{
 		DataCenter:  d.Get("datacenter").(string),
@@ -71,12 +71,11 @@ func resourceGlesysObjectStorageInstanceCreate(ctx context.Context, d *schema.Re
 }
 
 func resourceGlesysObjectStorageInstanceRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*glesys.Client)
+	client := m.(*apiClient)
 
 	instance, err := client.ObjectStorages.InstanceDetails(ctx, d.Id())
 	if err != nil {
-		d.SetId("")
-		return diag.Errorf("object storage not found: %s", err)
+		return readError(d, err, "object storage instance")
 	}
 
 	d.Set("datacenter", instance.DataCenter)
@@ -96,7 +95,7 @@ func resourceGlesysObjectStorageInstanceRead(ctx context.Context, d *schema.Reso
 }
 
 func resourceGlesysObjectStorageInstanceUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*glesys.Client)
+	client := m.(*apiClient)
 
 	params := glesys.EditObjectStorageInstanceParams{
 		InstanceID: d.Id(),
@@ -114,7 +113,7 @@ func resourceGlesysObjectStorageInstanceUpdate(ctx context.Context, d *schema.Re
 }
 
 func resourceGlesysObjectStorageInstanceDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*glesys.Client)
+	client := m.(*apiClient)
 
 	err := client.ObjectStorages.DeleteInstance(ctx, d.Id())
 	if err != nil {
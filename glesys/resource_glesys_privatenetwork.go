@@ -37,7 +37,7 @@ func resourceGlesysPrivateNetwork() *schema.Resource {
 }
 
 func resourceGlesysPrivateNetworkCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*glesys.Client)
+	client := m.(*apiClient)
 
 	name := d.Get("name").(string)
 	network, err := client.PrivateNetworks.Create(ctx, name)
@@ -52,14 +52,12 @@ func resourceGlesysPrivateNetworkCreate(ctx context.Context, d *schema.ResourceD
 }
 
 func resourceGlesysPrivateNetworkRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*glesys.Client)
+	client := m.(*apiClient)
 
 	network, err := client.PrivateNetworks.Details(ctx, d.Id())
 
 	if err != nil {
-		diag.Errorf("privatenetwork not found: %v", err)
-		d.SetId("")
-		return nil
+		return readError(d, err, "privatenetwork")
 	}
 
 	d.Set("ipv6aggregate", network.IPv6Aggregate)
@@ -69,7 +67,7 @@ func resourceGlesysPrivateNetworkRead(ctx context.Context, d *schema.ResourceDat
 }
 
 func resourceGlesysPrivateNetworkUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*glesys.Client)
+	client := m.(*apiClient)
 
 	params := glesys.EditPrivateNetworkParams{ID: d.Id()}
 
@@ -86,7 +84,7 @@ func resourceGlesysPrivateNetworkUpdate(ctx context.Context, d *schema.ResourceD
 }
 
 func resourceGlesysPrivateNetworkDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*glesys.Client)
+	client := m.(*apiClient)
 
 	err := client.PrivateNetworks.Destroy(ctx, d.Id())
 	if err != nil {
@@ -61,7 +61,7 @@ func resourceGlesysPrivateNetworkSegment() *schema.Resource {
 }
 
 func resourceGlesysPrivateNetworkSegmentCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*glesys.Client)
+	client := m.(*apiClient)
 
 	params := glesys.CreatePrivateNetworkSegmentParams{
 		PrivateNetworkID: d.Get("privatenetworkid").(string),
@@ -84,15 +84,13 @@ func resourceGlesysPrivateNetworkSegmentCreate(ctx context.Context, d *schema.Re
 }
 
 func resourceGlesysPrivateNetworkSegmentRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*glesys.Client)
+	client := m.(*apiClient)
 
 	// List segments for 'privatenetworkid'
 	segments, err := client.PrivateNetworks.ListSegments(ctx, d.Get("privatenetworkid").(string))
 
 	if err != nil {
-		diag.Errorf("privatenetwork not found: %v", err)
-		d.SetId("")
-		return nil
+		return readError(d, err, "privatenetwork segment")
 	}
 
 	for _, seg := range *segments {
@@ -109,7 +107,7 @@ func resourceGlesysPrivateNetworkSegmentRead(ctx context.Context, d *schema.Reso
 }
 
 func resourceGlesysPrivateNetworkSegmentUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*glesys.Client)
+	client := m.(*apiClient)
 
 	params := glesys.EditPrivateNetworkSegmentParams{ID: d.Id()}
 
@@ -126,7 +124,7 @@ func resourceGlesysPrivateNetworkSegmentUpdate(ctx context.Context, d *schema.Re
 }
 
 func resourceGlesysPrivateNetworkSegmentDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*glesys.Client)
+	client := m.(*apiClient)
 
 	err := client.PrivateNetworks.DestroySegment(ctx, d.Id())
 	if err != nil {
@@ -2,13 +2,17 @@ package glesys
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
+	"slices"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/glesys/glesys-go/v8"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -20,19 +24,33 @@ func resourceGlesysServer() *schema.Resource {
 		ReadContext:   resourceGlesysServerRead,
 		UpdateContext: resourceGlesysServerUpdate,
 		DeleteContext: resourceGlesysServerDelete,
+		CustomizeDiff: customdiff.Sequence(
+			resourceGlesysServerValidateArguments,
+			resourceGlesysServerImmutableAttributes,
+			resourceGlesysServerPreventShrink,
+			resourceGlesysServerValidateCloudConfig,
+			resourceGlesysServerEstimateCost,
+		),
 
 		Description: "Create a new Glesys virtual server.",
 
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: resourceGlesysServerImport,
 		},
 
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
 			Delete: schema.DefaultTimeout(10 * time.Minute),
 		},
 
 		Schema: map[string]*schema.Schema{
+			"allow_shrink_by_replace": {
+				Description: "Replace the server when `storage` is decreased, as the disk of a server can't be shrunk. The data on the server is lost. When not set, decreasing `storage` fails the plan.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
 			"bandwidth": {
 				Description: "Server network adapter bandwidth",
 				Type:        schema.TypeInt,
@@ -65,6 +83,7 @@ func resourceGlesysServer() *schema.Resource {
 				Required:    true,
 				ForceNew:    true,
 			},
+			"estimated_cost": estimatedCostSchema(),
 			"description": {
 				Description: "Server description",
 				Type:        schema.TypeString,
@@ -77,13 +96,13 @@ func resourceGlesysServer() *schema.Resource {
 				DiffSuppressFunc: IgnoreCase,
 			},
 			"ipv4_address": {
-				Description: "Server IPv4 address, set `none` to disable IP allocation",
+				Description: "Server IPv4 address, set `none` to disable IP allocation. Changing the address moves the server to it, the old address is released from the project unless `keepip` is set.",
 				Type:        schema.TypeString,
 				Optional:    true,
 				Computed:    true,
 			},
 			"ipv6_address": {
-				Description: "Server IPv6 address, set `none` to disable IP allocation",
+				Description: "Server IPv6 address, set `none` to disable IP allocation. Changing the address moves the server to it, the old address is released from the project unless `keepip` is set.",
 				Type:        schema.TypeString,
 				Optional:    true,
 				Computed:    true,
@@ -99,6 +118,18 @@ func resourceGlesysServer() *schema.Resource {
 				Type:        schema.TypeInt,
 				Required:    true,
 			},
+			"on_immutable_change": {
+				Description:  "What to do when `user`, `publickey`, `cloudconfig`, `cloudconfigparams`, `password` or `campaigncode` is changed, as they can only be set when the server is created. `error` (default) fails the plan, `replace` replaces the server. On imported servers they can be set once without either, as their values aren't known.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "error",
+				ValidateFunc: validation.StringInSlice([]string{"error", "replace"}, false),
+			},
+			"imported": {
+				Description: "Whether the server was imported, rather than created by Terraform.",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
 			"password": {
 				Description: "Server root password, VMware only",
 				Type:        schema.TypeString,
@@ -111,6 +142,13 @@ func resourceGlesysServer() *schema.Resource {
 				Optional:    true,
 				ForceNew:    true,
 			},
+			"power_state": {
+				Description:  "Server power state, `running` or `stopped`. The server is started or stopped to match.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice([]string{"running", "stopped"}, false),
+			},
 			"publickey": {
 				Description: "",
 				Type:        schema.TypeString,
@@ -126,6 +164,26 @@ func resourceGlesysServer() *schema.Resource {
 				Type:        schema.TypeBool,
 				Computed:    true,
 			},
+			"reboot_trigger": {
+				Description: "Arbitrary map of values that, when changed, reboots the server. The server is not rebooted while `power_state` is `stopped`.",
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"source_backup_id": {
+				Description:  "ID of a backup of `source_server_id` to restore the server from, see the `glesys_server_backups` data source. KVM only.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				RequiredWith: []string{"source_server_id"},
+			},
+			"source_server_id": {
+				Description:   "ID of a server to create the server as a clone of, instead of installing `template`. Set `source_backup_id` to restore one of its backups instead of its current disk. The clone keeps the users, keys and passwords of the source server.",
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"template", "cloudconfig", "password", "publickey", "user"},
+			},
 			"storage": {
 				Description: "Server disk space",
 				Type:        schema.TypeInt,
@@ -138,6 +196,24 @@ func resourceGlesysServer() *schema.Resource {
 				ForceNew:    true,
 			},
 
+			"template_drift": {
+				Description:  "Set to `warn` to show a warning when the tag in `template` now points to a different image than the one the server was installed from. Defaults to `ignore`.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "ignore",
+				ValidateFunc: validation.StringInSlice([]string{"ignore", "warn"}, false),
+			},
+			"template_id": {
+				Description: "ID of the template the server was installed from.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"template_name": {
+				Description: "Name of the template the server was installed from.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+
 			"extra_disks": {
 				Description: "Disks associated with the server. Use `glesys_server_disk` resource to manage these.",
 				Type:        schema.TypeList,
@@ -296,9 +372,250 @@ func buildServerParamStruct(d *schema.ResourceData) *glesys.CreateServerParams {
 	return &opts
 }
 
+// serverArguments maps server attributes to the server/allowedarguments
+// argument holding their valid values.
+var serverArguments = []struct {
+	attribute string
+	argument  string
+}{
+	{"datacenter", "datacenter"},
+	{"cpu", "cpucores"},
+	{"memory", "memorysize"},
+	{"storage", "disksize"},
+	{"bandwidth", "bandwidth"},
+	{"template", "templatename"},
+}
+
+// resourceGlesysServerValidateArguments checks the server sizing against the
+// values the API accepts for the platform, so that invalid combinations are
+// caught at plan time instead of failing the create or update.
+func resourceGlesysServerValidateArguments(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	client := m.(*apiClient)
+
+	if !d.NewValueKnown("platform") {
+		return nil
+	}
+	platform := d.Get("platform").(string)
+	if platform == "" {
+		platform = "KVM"
+	}
+
+	// Only check what is about to be sent to the API, values of existing
+	// servers may no longer be offered.
+	var attributes []string
+	for _, arg := range serverArguments {
+		if d.Id() != "" && !d.HasChange(arg.attribute) {
+			continue
+		}
+		if !d.NewValueKnown(arg.attribute) || serverArgumentValue(d, arg.attribute) == "" {
+			continue
+		}
+		attributes = append(attributes, arg.attribute)
+	}
+	if len(attributes) == 0 {
+		return nil
+	}
+
+	allowed, err := client.serverAllowedArguments(ctx, platform)
+	if err != nil {
+		log.Printf("[WARN] unable to fetch allowed server arguments, skipping validation: %s", err)
+		return nil
+	}
+	if allowed == nil {
+		if platforms := client.serverPlatforms(); len(platforms) > 0 {
+			return fmt.Errorf("platform: %q is not a valid platform, valid values are: %s", platform, strings.Join(platforms, ", "))
+		}
+		return nil
+	}
+
+	var errs []error
+	for _, arg := range serverArguments {
+		valid, ok := allowed[arg.argument]
+		if !ok || !slices.Contains(attributes, arg.attribute) {
+			continue
+		}
+
+		value := serverArgumentValue(d, arg.attribute)
+		if slices.ContainsFunc(valid, func(v string) bool { return strings.EqualFold(v, value) }) {
+			continue
+		}
+		if arg.attribute == "template" && isServerTemplateReference(ctx, client, platform, value) {
+			continue
+		}
+
+		errs = append(errs, fmt.Errorf("%s: %s is not available on %s, valid values are: %s",
+			arg.attribute, value, platform, strings.Join(valid, ", ")))
+	}
+
+	return errors.Join(errs...)
+}
+
+// serverCreationAttributes are only sent to the API when the server is
+// created.
+var serverCreationAttributes = []string{"user", "publickey", "cloudconfig", "cloudconfigparams", "password", "campaigncode"}
+
+// resourceGlesysServerImmutableAttributes replaces the server or fails the
+// plan, depending on on_immutable_change, when attributes that can't be
+// updated are changed. The attributes of imported servers aren't known, so
+// setting them the first time isn't treated as a change.
+func resourceGlesysServerImmutableAttributes(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+
+	imported := d.Get("imported").(bool)
+	var changed []string
+	for _, attr := range serverCreationAttributes {
+		old, _ := d.GetChange(attr)
+		if d.HasChange(attr) && !(imported && isEmptyValue(old)) {
+			changed = append(changed, attr)
+		}
+	}
+	if len(changed) == 0 {
+		return nil
+	}
+
+	if d.Get("on_immutable_change").(string) == "replace" {
+		for _, attr := range changed {
+			if err := d.ForceNew(attr); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	return fmt.Errorf("%s can only be set when the server is created, revert the change or set on_immutable_change = \"replace\" to replace the server",
+		strings.Join(changed, ", "))
+}
+
+// isEmptyValue reports whether v is the zero value of a string, map or set
+// attribute.
+func isEmptyValue(v interface{}) bool {
+	switch v := v.(type) {
+	case string:
+		return v == ""
+	case map[string]interface{}:
+		return len(v) == 0
+	case *schema.Set:
+		return v.Len() == 0
+	}
+	return v == nil
+}
+
+// resourceGlesysServerImport marks the server as imported, see
+// resourceGlesysServerImmutableAttributes.
+func resourceGlesysServerImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	d.Set("imported", true)
+	return []*schema.ResourceData{d}, nil
+}
+
+// resourceGlesysServerPreventShrink guards against shrinking the disk of the
+// server.
+func resourceGlesysServerPreventShrink(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	return preventShrink(d, "storage", "server")
+}
+
+// resourceGlesysServerValidateCloudConfig renders cloudconfig with the
+// preview API, and checks it the same way as the glesys_cloudconfig data
+// source.
+func resourceGlesysServerValidateCloudConfig(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	client := m.(*apiClient)
+
+	if !d.HasChange("cloudconfig") && !d.HasChange("cloudconfigparams") {
+		return nil
+	}
+	if !d.NewValueKnown("cloudconfig") || !d.NewValueKnown("cloudconfigparams") {
+		return nil
+	}
+	template := d.Get("cloudconfig").(string)
+	if template == "" {
+		return nil
+	}
+	params := d.Get("cloudconfigparams").(map[string]any)
+
+	if err := checkCloudConfigParams(template, params); err != nil {
+		return err
+	}
+
+	preview, err := client.Servers.PreviewCloudConfig(ctx, glesys.PreviewCloudConfigParams{
+		CloudConfig:       template,
+		CloudConfigParams: params,
+	})
+	if err != nil {
+		log.Printf("[WARN] unable to render cloudconfig, skipping validation: %s", err)
+		return nil
+	}
+	return checkRenderedCloudConfig(preview.Preview)
+}
+
+// resourceGlesysServerEstimateCost plans estimated_cost for new servers and
+// changes to the server sizing.
+func resourceGlesysServerEstimateCost(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	client := m.(*apiClient)
+
+	if !needsCostEstimate(d, "bandwidth", "cpu", "datacenter", "memory", "platform", "storage", "template") {
+		return keepCostEstimate(d)
+	}
+
+	billing, err := client.serverEstimatedCost(ctx, serverEstimatedCostParams{
+		ServerID:   d.Id(),
+		Bandwidth:  d.Get("bandwidth").(int),
+		CPU:        d.Get("cpu").(int),
+		DataCenter: d.Get("datacenter").(string),
+		Memory:     d.Get("memory").(int),
+		Platform:   d.Get("platform").(string),
+		Storage:    d.Get("storage").(int),
+		Template:   d.Get("template").(string),
+	})
+	if err != nil {
+		log.Printf("[WARN] unable to estimate the cost of server (%s): %s", d.Id(), err)
+		return nil
+	}
+
+	return setEstimatedCost(d, client, "server", billing)
+}
+
+// serverArgumentValue returns the planned value of attribute as a string, or
+// "" if it isn't set.
+func serverArgumentValue(d *schema.ResourceDiff, attribute string) string {
+	switch v := d.Get(attribute).(type) {
+	case int:
+		if v == 0 {
+			return ""
+		}
+		return strconv.Itoa(v)
+	case string:
+		return v
+	}
+	return ""
+}
+
+// isServerTemplateReference reports whether value is the ID or a tag of a
+// template on platform. Templates can be referred to by name, ID or tag, but
+// server/allowedarguments only lists names.
+func isServerTemplateReference(ctx context.Context, client *apiClient, platform string, value string) bool {
+	templates, err := client.serverTemplates(ctx)
+	if err != nil {
+		log.Printf("[WARN] unable to fetch server templates: %s", err)
+		return true
+	}
+
+	for p, list := range templates {
+		if !strings.EqualFold(p, platform) {
+			continue
+		}
+		for _, t := range list {
+			if t.ID == value || slices.Contains(t.CurrentTags, value) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func resourceGlesysServerCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	// Setup client to the API
-	client := m.(*glesys.Client)
+	client := m.(*apiClient)
 
 	// Setup server parameters
 	srv := buildServerParamStruct(d)
@@ -316,18 +633,39 @@ func resourceGlesysServerCreate(ctx context.Context, d *schema.ResourceData, m i
 	}
 	srv.Users = usersList
 
-	host, err := client.Servers.Create(ctx, *srv)
-	if err != nil {
-		return diag.Errorf("error creating server: %+v", err)
+	var host *glesys.ServerDetails
+	if sourceID, ok := d.GetOk("source_server_id"); ok {
+		host, err = client.cloneServer(ctx, cloneServerParams{
+			ServerID:    sourceID.(string),
+			BackupID:    d.Get("source_backup_id").(string),
+			Backup:      srv.Backup,
+			Bandwidth:   srv.Bandwidth,
+			CPU:         srv.CPU,
+			DataCenter:  srv.DataCenter,
+			Description: srv.Description,
+			Hostname:    srv.Hostname,
+			IPv4:        srv.IPv4,
+			IPv6:        srv.IPv6,
+			Memory:      srv.Memory,
+			Storage:     srv.Storage,
+		})
+		if err != nil {
+			return diag.Errorf("error cloning server %s: %+v", sourceID, err)
+		}
+	} else {
+		host, err = client.Servers.Create(ctx, *srv)
+		if err != nil {
+			return diag.Errorf("error creating server: %+v", err)
+		}
 	}
 
 	// Set the resource Id to server ID
 	d.SetId(host.ID)
 
-	if _, err = waitForServerAttribute(ctx, d, "true", []string{"false"}, "isrunning", m); err != nil {
+	if _, err = waitForServerAttribute(ctx, d, "true", []string{"false"}, "isrunning", d.Timeout(schema.TimeoutCreate), m); err != nil {
 		return diag.Errorf("error while waiting for Server (%s) to be started: %s", d.Id(), err)
 	}
-	if _, err = waitForServerAttribute(ctx, d, "false", []string{"true"}, "islocked", m); err != nil {
+	if _, err = waitForServerAttribute(ctx, d, "false", []string{"true"}, "islocked", d.Timeout(schema.TimeoutCreate), m); err != nil {
 		return diag.Errorf("error while waiting for Server (%s) to be completed: %s", d.Id(), err)
 	}
 
@@ -340,7 +678,14 @@ func resourceGlesysServerCreate(ctx context.Context, d *schema.ResourceData, m i
 		}
 	}
 
-	return resourceGlesysServerRead(ctx, d, m)
+	if d.Get("power_state").(string) == "stopped" {
+		if err := setServerPowerState(ctx, d, "stopped", d.Timeout(schema.TimeoutCreate), m); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	diags := resourceGlesysServerRead(ctx, d, m)
+	return append(diags, costWarning(d, m, "server")...)
 }
 
 func getTemplate(original string, srv *glesys.ServerDetails) string {
@@ -356,14 +701,12 @@ func getTemplate(original string, srv *glesys.ServerDetails) string {
 }
 
 func resourceGlesysServerRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*glesys.Client)
+	client := m.(*apiClient)
 
 	// fetch updates about the resource
 	srv, err := client.Servers.Details(ctx, d.Id())
 	if err != nil {
-		diag.Errorf("server not found: %s", err)
-		d.SetId("")
-		return nil
+		return readError(d, err, "server")
 	}
 
 	// Workaround for the API not returning the correct Bandwidth value for KVM servers
@@ -375,20 +718,56 @@ func resourceGlesysServerRead(ctx context.Context, d *schema.ResourceData, m int
 	d.Set("datacenter", srv.DataCenter)
 	d.Set("description", srv.Description)
 	d.Set("hostname", srv.Hostname)
-	for i := range srv.IPList {
-		if srv.IPList[i].Version == 4 {
-			d.Set("ipv4_address", srv.IPList[i].Address)
+	// Servers can have more than one IP of each version, keep the address in
+	// state as long as it is still on the server.
+	ipv4, ipv6 := serverAddresses(srv)
+	for _, ip := range srv.IPList {
+		if ip.Address == d.Get("ipv4_address").(string) {
+			ipv4 = ip.Address
 		}
-		if srv.IPList[i].Version == 6 {
-			d.Set("ipv6_address", srv.IPList[i].Address)
+		if ip.Address == d.Get("ipv6_address").(string) {
+			ipv6 = ip.Address
 		}
 	}
+	if ipv4 != "" {
+		d.Set("ipv4_address", ipv4)
+	}
+	if ipv6 != "" {
+		d.Set("ipv6_address", ipv6)
+	}
 	d.Set("memory", srv.Memory)
 	d.Set("platform", srv.Platform)
 	d.Set("islocked", srv.IsLocked)
 	d.Set("isrunning", srv.IsRunning)
+	if srv.IsRunning {
+		d.Set("power_state", "running")
+	} else {
+		d.Set("power_state", "stopped")
+	}
 	d.Set("storage", srv.Storage)
-	d.Set("template", getTemplate(d.Get("template").(string), srv))
+
+	// A tag in template that has moved to a newer image still describes the
+	// server, it is kept so that the server isn't replaced. Clones aren't
+	// installed from a template, template is left unset.
+	var diags diag.Diagnostics
+	template := d.Get("template").(string)
+	if _, clone := d.GetOk("source_server_id"); !clone {
+		if current := movedServerTemplateTag(ctx, client, template, srv); current != nil {
+			if d.Get("template_drift").(string) == "warn" {
+				diags = append(diags, diag.Diagnostic{
+					Severity: diag.Warning,
+					Summary:  fmt.Sprintf("Template %q of server %s points to a different image", template, srv.ID),
+					Detail: fmt.Sprintf("The server was installed from %s (%s), but %q now points to %s (%s). Replace the server to install the new image.",
+						srv.InitialTemplate.Name, srv.InitialTemplate.ID, template, current.Name, current.ID),
+				})
+			}
+			d.Set("template", template)
+		} else {
+			d.Set("template", getTemplate(template, srv))
+		}
+	}
+	d.Set("template_id", srv.InitialTemplate.ID)
+	d.Set("template_name", srv.InitialTemplate.Name)
 	var diskIDs []string
 	for _, d := range srv.AdditionalDisks {
 		diskIDs = append(diskIDs, d.ID)
@@ -409,7 +788,10 @@ func resourceGlesysServerRead(ctx context.Context, d *schema.ResourceData, m int
 	}
 
 	var adapters []map[string]interface{}
-	netAdapters, _ := client.Servers.NetworkAdapters(ctx, d.Id())
+	netAdapters, err := client.Servers.NetworkAdapters(ctx, d.Id())
+	if err != nil {
+		return readError(d, err, "server network adapters")
+	}
 	for _, v := range *netAdapters {
 		n := map[string]interface{}{
 			"id":          v.ID,
@@ -433,11 +815,38 @@ func resourceGlesysServerRead(ctx context.Context, d *schema.ResourceData, m int
 		"host": d.Get("ipv4_address").(string),
 	})
 
+	return diags
+}
+
+// movedServerTemplateTag returns the template tag points to, when tag no
+// longer points at the template the server was installed from. It returns nil
+// if tag isn't a template tag or still points at the installed template.
+func movedServerTemplateTag(ctx context.Context, client *apiClient, tag string, srv *glesys.ServerDetails) *serverTemplate {
+	if tag == "" || tag == srv.InitialTemplate.ID || slices.Contains(srv.InitialTemplate.CurrentTags, tag) {
+		return nil
+	}
+
+	templates, err := client.serverTemplates(ctx)
+	if err != nil {
+		log.Printf("[WARN] unable to fetch server templates, skipping template drift check: %s", err)
+		return nil
+	}
+
+	for p, list := range templates {
+		if !strings.EqualFold(p, srv.Platform) {
+			continue
+		}
+		for _, t := range list {
+			if t.ID != srv.InitialTemplate.ID && slices.Contains(t.CurrentTags, tag) {
+				return &t
+			}
+		}
+	}
 	return nil
 }
 
 func resourceGlesysServerUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*glesys.Client)
+	client := m.(*apiClient)
 
 	opts := glesys.EditServerParams{}
 
@@ -466,6 +875,14 @@ func resourceGlesysServerUpdate(ctx context.Context, d *schema.ResourceData, m i
 		}
 		opts.Backup = backupsList
 	}
+
+	serverMutexKV.Lock(d.Id())
+	defer serverMutexKV.Unlock(d.Id())
+
+	if _, err := waitForServerAttribute(ctx, d, "false", []string{"true"}, "islocked", d.Timeout(schema.TimeoutUpdate), m); err != nil {
+		return diag.Errorf("Error waiting for server to be unlocked for update (%s): %s", d.Id(), err)
+	}
+
 	_, err := client.Servers.Edit(ctx, d.Id(), opts)
 	if err != nil {
 		return diag.Errorf("Error updating instance: %s", err)
@@ -478,14 +895,42 @@ func resourceGlesysServerUpdate(ctx context.Context, d *schema.ResourceData, m i
 		}
 	}
 
-	return resourceGlesysServerRead(ctx, d, m)
+	for version, attr := range map[int]string{4: "ipv4_address", 6: "ipv6_address"} {
+		if !d.HasChange(attr) {
+			continue
+		}
+		oldAddress, newAddress := d.GetChange(attr)
+		if err := swapServerIP(ctx, d, client, version, oldAddress.(string), newAddress.(string)); err != nil {
+			return diag.Errorf("error while updating %s: %s", attr, err)
+		}
+	}
+
+	powerState := d.Get("power_state").(string)
+	if d.HasChange("power_state") {
+		if err := setServerPowerState(ctx, d, powerState, d.Timeout(schema.TimeoutUpdate), m); err != nil {
+			return diag.FromErr(err)
+		}
+	} else if d.HasChange("reboot_trigger") && powerState != "stopped" {
+		if err := rebootServer(ctx, d, d.Timeout(schema.TimeoutUpdate), m); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	diags := resourceGlesysServerRead(ctx, d, m)
+	if d.HasChange("estimated_cost") {
+		diags = append(diags, costWarning(d, m, "server")...)
+	}
+	return diags
 }
 
-func setServerNetworkAdapter(ctx context.Context, d *schema.ResourceData, client *glesys.Client) error {
+func setServerNetworkAdapter(ctx context.Context, d *schema.ResourceData, client *apiClient) error {
 	netadapterparams := glesys.EditNetworkAdapterParams{}
 	var netadapterID string
 	// fetch current networkadapters
-	netAdapters, _ := client.Servers.NetworkAdapters(ctx, d.Id())
+	netAdapters, err := client.Servers.NetworkAdapters(ctx, d.Id())
+	if err != nil {
+		return fmt.Errorf("error retrieving network adapters: %s", err)
+	}
 	for _, v := range *netAdapters {
 		if v.Name == "Network adapter 1" || v.IsPrimary {
 			netadapterID = v.ID
@@ -495,7 +940,7 @@ func setServerNetworkAdapter(ctx context.Context, d *schema.ResourceData, client
 	log.Printf("[INFO]: setServerNetworkAdapter (%s) networkadapter found %s", d.Id(), netadapterID)
 	netadapterparams.NetworkID = d.Get("primary_networkadapter_network").(string)
 
-	_, err := client.NetworkAdapters.Edit(ctx, netadapterID, netadapterparams)
+	_, err = client.NetworkAdapters.Edit(ctx, netadapterID, netadapterparams)
 	if err != nil {
 		return err
 	}
@@ -503,11 +948,118 @@ func setServerNetworkAdapter(ctx context.Context, d *schema.ResourceData, client
 	return nil
 }
 
+// swapServerIP adds the new address to the server, and then removes the old
+// one, so that the server keeps its old address if the new one can't be
+// added. The old address is released from the project unless keepip is set.
+// Addresses can be `none` for no address, or `any` for a new address.
+func swapServerIP(ctx context.Context, d *schema.ResourceData, client *apiClient, version int, oldAddress string, newAddress string) error {
+	// The state keeps the old address until the new one has been added.
+	attr := fmt.Sprintf("ipv%d_address", version)
+	d.Set(attr, oldAddress)
+
+	switch newAddress {
+	case "", "none":
+	case "any":
+		free, err := client.IPs.Available(ctx, glesys.AvailableIPsParams{
+			DataCenter: d.Get("datacenter").(string),
+			Platform:   d.Get("platform").(string),
+			Version:    version,
+		})
+		if err != nil {
+			return fmt.Errorf("error listing available IPs: %s", err)
+		}
+		if len(*free) == 0 {
+			return fmt.Errorf("no IPv%d addresses available", version)
+		}
+		ip, err := client.IPs.Reserve(ctx, (*free)[0].Address)
+		if err != nil {
+			return fmt.Errorf("error reserving %s: %s", (*free)[0].Address, err)
+		}
+		if err := client.addServerIP(ctx, d.Id(), ip.Address); err != nil {
+			if err := client.IPs.Release(ctx, ip.Address); err != nil {
+				log.Printf("[WARN] unable to release %s: %s", ip.Address, err)
+			}
+			return fmt.Errorf("error adding %s to server: %s", ip.Address, err)
+		}
+		newAddress = ip.Address
+	default:
+		if err := client.addServerIP(ctx, d.Id(), newAddress); err != nil {
+			return fmt.Errorf("error adding %s to server: %s", newAddress, err)
+		}
+	}
+
+	d.Set(attr, newAddress)
+
+	if oldAddress != "" && oldAddress != "none" {
+		if err := client.removeServerIP(ctx, oldAddress, !d.Get("keepip").(bool)); err != nil {
+			return fmt.Errorf("error removing %s from server: %s", oldAddress, err)
+		}
+	}
+	return nil
+}
+
+// setServerPowerState starts or stops the server and waits until it is in
+// state, `running` or `stopped`.
+func setServerPowerState(ctx context.Context, d *schema.ResourceData, state string, timeout time.Duration, m interface{}) error {
+	client := m.(*apiClient)
+
+	if state == "running" {
+		if err := client.Servers.Start(ctx, d.Id()); err != nil {
+			return fmt.Errorf("error starting server (%s): %s", d.Id(), err)
+		}
+		if _, err := waitForServerAttribute(ctx, d, "true", []string{"false"}, "isrunning", timeout, m); err != nil {
+			return fmt.Errorf("error while waiting for Server (%s) to be started: %s", d.Id(), err)
+		}
+	} else {
+		if err := client.Servers.Stop(ctx, d.Id(), glesys.StopServerParams{Type: "soft"}); err != nil {
+			return fmt.Errorf("error stopping server (%s): %s", d.Id(), err)
+		}
+		if _, err := waitForServerAttribute(ctx, d, "false", []string{"true"}, "isrunning", timeout, m); err != nil {
+			return fmt.Errorf("error while waiting for Server (%s) to be stopped: %s", d.Id(), err)
+		}
+	}
+
+	if _, err := waitForServerAttribute(ctx, d, "false", []string{"true"}, "islocked", timeout, m); err != nil {
+		return fmt.Errorf("error while waiting for Server (%s) to be unlocked: %s", d.Id(), err)
+	}
+	return nil
+}
+
+// rebootStartTimeout bounds the wait for a rebooting server to go down.
+var rebootStartTimeout = 2 * time.Minute
+
+// rebootServer gracefully reboots the server and waits until it is running
+// again.
+func rebootServer(ctx context.Context, d *schema.ResourceData, timeout time.Duration, m interface{}) error {
+	client := m.(*apiClient)
+
+	if err := client.Servers.Stop(ctx, d.Id(), glesys.StopServerParams{Type: "reboot"}); err != nil {
+		return fmt.Errorf("error rebooting server (%s): %s", d.Id(), err)
+	}
+	// The reboot is done in the background, so wait for the server to go down
+	// before waiting for it to come back up. A quick reboot may not be seen
+	// between two polls, so the wait is short and a timeout isn't an error.
+	_, err := waitForServerAttribute(ctx, d, "false", []string{"true"}, "isavailable", min(timeout, rebootStartTimeout), m)
+	var timeoutErr *retry.TimeoutError
+	if errors.As(err, &timeoutErr) {
+		log.Printf("[INFO] Server (%s) wasn't seen going down within %s, assuming it has rebooted", d.Id(), rebootStartTimeout)
+	} else if err != nil {
+		return fmt.Errorf("error while waiting for Server (%s) to start rebooting: %s", d.Id(), err)
+	}
+	if _, err := waitForServerAttribute(ctx, d, "true", []string{"false"}, "isavailable", timeout, m); err != nil {
+		return fmt.Errorf("error while waiting for Server (%s) to be rebooted: %s", d.Id(), err)
+	}
+	return nil
+}
+
 func resourceGlesysServerDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*glesys.Client)
+	client := m.(*apiClient)
+
+	serverMutexKV.Lock(d.Id())
+	defer serverMutexKV.Unlock(d.Id())
 
 	// Call waitForServerAttribute to make sure the server isn't locked before deleting it.
-	_, err := waitForServerAttribute(ctx, d, "false", []string{"true"}, "islocked", m)
+	_, err := waitForServerAttribute(ctx, d, "false", []string{"true"}, "islocked", d.Timeout(schema.TimeoutDelete), m)
 	if err != nil {
 		return diag.Errorf("Error waiting for server to be unlocked for destroy (%s): %s", d.Id(), err)
 	}
@@ -520,22 +1072,23 @@ func resourceGlesysServerDelete(ctx context.Context, d *schema.ResourceData, m i
 	return nil
 }
 
-// waitForServerAttribute
+// waitForServerAttribute waits until attribute of the server reaches target,
+// or timeout is reached.
 func waitForServerAttribute(
-	ctx context.Context, d *schema.ResourceData, target string, pending []string, attribute string, m interface{}) (interface{}, error) {
-	stateConf := &retry.StateChangeConf{
-		Pending:    pending,
-		Target:     []string{target},
-		Refresh:    serverStateRefresh(ctx, d, m, attribute),
-		Timeout:    20 * time.Minute,
-		Delay:      6 * time.Second,
-		MinTimeout: 3 * time.Second,
-	}
+	ctx context.Context, d *schema.ResourceData, target string, pending []string, attribute string, timeout time.Duration, m interface{}) (interface{}, error) {
+	client := m.(*apiClient)
+
+	stateConf := client.stateChangeConf(&retry.StateChangeConf{
+		Pending: pending,
+		Target:  []string{target},
+		Refresh: serverStateRefresh(ctx, d, m, attribute),
+		Timeout: timeout,
+	}, 6*time.Second, 3*time.Second)
 	return stateConf.WaitForStateContext(ctx)
 }
 
 func serverStateRefresh(ctx context.Context, d *schema.ResourceData, m interface{}, attr string) retry.StateRefreshFunc {
-	client := m.(*glesys.Client)
+	client := m.(*apiClient)
 	return func() (interface{}, string, error) {
 		// check state of server
 		server, err := client.Servers.Details(ctx, d.Id())
@@ -552,6 +1105,10 @@ func serverStateRefresh(ctx context.Context, d *schema.ResourceData, m interface
 			running := strconv.FormatBool(server.IsRunning)
 			log.Printf("[INFO] Server (%s) started: %s", d.Id(), running)
 			return server, running, nil
+		case "isavailable":
+			available := strconv.FormatBool(server.IsRunning && !server.IsLocked)
+			log.Printf("[INFO] Server (%s) running and unlocked: %s", d.Id(), available)
+			return server, available, nil
 		default:
 			return nil, "", nil
 		}
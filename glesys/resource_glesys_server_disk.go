@@ -3,12 +3,14 @@ package glesys
 import (
 	"context"
 	"fmt"
+	"log"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/glesys/glesys-go/v8"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -22,16 +24,35 @@ func resourceGlesysServerDisk() *schema.Resource {
 
 		Description: "An additional disk associated with a `glesys_server`",
 
+		CustomizeDiff: customdiff.Sequence(
+			resourceGlesysServerDiskValidateSize,
+			resourceGlesysServerDiskImmutableType,
+			resourceGlesysServerDiskEstimateCost,
+		),
+
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceGlesysServerDiskImport,
 		},
 
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+
 		Schema: map[string]*schema.Schema{
+			"allow_shrink_by_replace": {
+				Description: "Replace the disk when `size` is decreased, as disks can't be shrunk. The data on the disk is lost. When not set, decreasing `size` fails the plan.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
 			"id": {
 				Description: "Disk ID.",
 				Type:        schema.TypeString,
 				Computed:    true,
 			},
+			"estimated_cost": estimatedCostSchema(),
 			"name": {
 				Description: "Disk descriptive name.",
 				Type:        schema.TypeString,
@@ -54,16 +75,17 @@ func resourceGlesysServerDisk() *schema.Resource {
 				Computed:    true,
 			},
 			"type": {
-				Description: "Disk type [gold|silver]",
+				Description: "Disk type [gold|silver]. The type of an existing disk can't be changed, changing it fails the plan unless the disk is replaced anyway, because `serverid` changes or the disk is shrunk with `allow_shrink_by_replace`.",
 				Type:        schema.TypeString,
 				Optional:    true,
-				ForceNew:    true,
+				Computed:    true,
 			},
 		},
 	}
 }
 
 // resourceGlesysServerDiskImport - import additional disks "wps12345,000000-1111-222-3333333"
+// or by disk ID only, in which case Read looks up the server.
 func resourceGlesysServerDiskImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
 	if strings.Contains(d.Id(), ",") {
 		s := strings.Split(d.Id(), ",")
@@ -80,7 +102,7 @@ func resourceGlesysServerDiskImport(ctx context.Context, d *schema.ResourceData,
 }
 func resourceGlesysServerDiskCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	// Setup client to the API
-	client := m.(*glesys.Client)
+	client := m.(*apiClient)
 
 	// Setup server parameters
 	params := glesys.CreateServerDiskParams{
@@ -90,8 +112,11 @@ func resourceGlesysServerDiskCreate(ctx context.Context, d *schema.ResourceData,
 		Type:      d.Get("type").(string),
 	}
 
+	serverMutexKV.Lock(params.ServerID)
+	defer serverMutexKV.Unlock(params.ServerID)
+
 	// Wait for server to be running && !islocked
-	if _, err := waitForServerLocked(ctx, params.ServerID, "false", []string{"true"}, "islocked", m); err != nil {
+	if _, err := waitForServerLocked(ctx, params.ServerID, "false", []string{"true"}, "islocked", d.Timeout(schema.TimeoutCreate), m); err != nil {
 		return diag.Errorf("disk: error while waiting for Server (%s) to be completed: %s", params.ServerID, err)
 	}
 	disk, err := client.ServerDisks.Create(ctx, params)
@@ -103,24 +128,130 @@ func resourceGlesysServerDiskCreate(ctx context.Context, d *schema.ResourceData,
 	// Set the resource Id to server ID
 	d.SetId(disk.ID)
 
-	return resourceGlesysServerDiskRead(ctx, d, m)
+	diags := resourceGlesysServerDiskRead(ctx, d, m)
+	return append(diags, costWarning(d, m, "server disk")...)
 }
 
-func waitForServerLocked(ctx context.Context, serverID string, target string, pending []string, attribute string, meta interface{}) (interface{}, error) {
-	stateConf := &retry.StateChangeConf{
+// resourceGlesysServerDiskValidateSize checks the planned size against the
+// disk limits of the server, and guards against shrinking the disk.
+func resourceGlesysServerDiskValidateSize(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	client := m.(*apiClient)
+
+	if err := preventShrink(d, "size", "disk"); err != nil {
+		return err
+	}
+
+	if d.Id() != "" && !d.HasChange("size") {
+		return nil
+	}
+	if !d.NewValueKnown("size") || !d.NewValueKnown("serverid") {
+		return nil
+	}
+
+	serverID := d.Get("serverid").(string)
+	limits, err := client.ServerDisks.Limits(ctx, serverID)
+	if err != nil {
+		log.Printf("[WARN] unable to fetch disk limits of server (%s), skipping validation: %s", serverID, err)
+		return nil
+	}
+
+	size := d.Get("size").(int)
+	if size < limits.MinSizeInGIB || size > limits.MaxSizeInGIB {
+		return fmt.Errorf("size: %d GIB is outside the disk limits of server %s, valid sizes are %d to %d GIB",
+			size, serverID, limits.MinSizeInGIB, limits.MaxSizeInGIB)
+	}
+	return nil
+}
+
+// resourceGlesysServerDiskImmutableType fails the plan when the type of an
+// existing disk is changed. serverdisk/reconfigure can only change the size,
+// and replacing the disk would silently lose its data. Disks that are
+// replaced anyway, as they move to another server or are shrunk by replacing
+// them, are created with the new type.
+func resourceGlesysServerDiskImmutableType(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() == "" || !d.HasChange("type") || !d.NewValueKnown("type") {
+		return nil
+	}
+	if d.HasChange("serverid") || shrinkByReplace(d, "size") {
+		return nil
+	}
+
+	o, n := d.GetChange("type")
+	return fmt.Errorf("type: the disk can't be changed from %s to %s in place, moving it to another type requires migrating the data. "+
+		"Revert the change, or add a new glesys_server_disk of type %s and copy the data to it. "+
+		"To recreate the disk without its data, remove it with terraform state rm, apply to create a new disk and delete the old one in GleSYS Cloud",
+		o, n, n)
+}
+
+// shrinkByReplace reports whether the disk size in attr is decreased and
+// allow_shrink_by_replace is set, so that the resource is replaced.
+func shrinkByReplace(d *schema.ResourceDiff, attr string) bool {
+	if !d.HasChange(attr) || !d.NewValueKnown(attr) {
+		return false
+	}
+	o, n := d.GetChange(attr)
+	return n.(int) < o.(int) && d.Get("allow_shrink_by_replace").(bool)
+}
+
+// preventShrink fails the plan when the disk size in attr is decreased, or
+// replaces the resource when allow_shrink_by_replace is set. Disks can only
+// grow in place.
+func preventShrink(d *schema.ResourceDiff, attr string, resource string) error {
+	if d.Id() == "" || !d.HasChange(attr) || !d.NewValueKnown(attr) {
+		return nil
+	}
+
+	o, n := d.GetChange(attr)
+	if n.(int) >= o.(int) {
+		return nil
+	}
+	if shrinkByReplace(d, attr) {
+		return d.ForceNew(attr)
+	}
+
+	return fmt.Errorf("%s: can't be decreased from %d to %d GIB as disks can't be shrunk, revert the change or set allow_shrink_by_replace = true to replace the %s, losing its data",
+		attr, o, n, resource)
+}
+
+// resourceGlesysServerDiskEstimateCost plans estimated_cost for new disks and
+// changes to the disk size.
+func resourceGlesysServerDiskEstimateCost(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	client := m.(*apiClient)
+
+	if !needsCostEstimate(d, "serverid", "size", "type") {
+		return keepCostEstimate(d)
+	}
+
+	billing, err := client.serverDiskEstimatedCost(ctx, serverDiskEstimatedCostParams{
+		ServerID:  d.Get("serverid").(string),
+		SizeInGIB: d.Get("size").(int),
+		Type:      d.Get("type").(string),
+	})
+	if err != nil {
+		log.Printf("[WARN] unable to estimate the cost of server disk (%s): %s", d.Id(), err)
+		return nil
+	}
+
+	return setEstimatedCost(d, client, "server disk", billing)
+}
+
+// waitForServerLocked waits until the locked state of the server is target,
+// or timeout is reached.
+func waitForServerLocked(ctx context.Context, serverID string, target string, pending []string, attribute string, timeout time.Duration, meta interface{}) (interface{}, error) {
+	client := meta.(*apiClient)
+
+	stateConf := client.stateChangeConf(&retry.StateChangeConf{
 		Pending:        pending,
 		Target:         []string{target},
 		Refresh:        serverdiskStateRefresh(ctx, serverID, meta),
-		Timeout:        10 * time.Minute,
-		Delay:          10 * time.Second,
-		MinTimeout:     3 * time.Second,
+		Timeout:        timeout,
 		NotFoundChecks: 60,
-	}
+	}, 10*time.Second, 3*time.Second)
 
 	return stateConf.WaitForStateContext(ctx)
 }
 func serverdiskStateRefresh(ctx context.Context, serverID string, meta interface{}) retry.StateRefreshFunc {
-	client := meta.(*glesys.Client)
+	client := meta.(*apiClient)
 
 	return func() (interface{}, string, error) {
 		server, err := client.Servers.Details(ctx, serverID)
@@ -132,35 +263,84 @@ func serverdiskStateRefresh(ctx context.Context, serverID string, meta interface
 }
 
 func resourceGlesysServerDiskRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*glesys.Client)
+	client := m.(*apiClient)
 
+	// Disks imported by disk ID only don't know their server yet.
 	serverid := d.Get("serverid").(string)
+	if serverid == "" {
+		id, err := findServerDiskServer(ctx, client, d.Id())
+		if err != nil {
+			return diag.Errorf("Error looking up the server of disk (%s): %s", d.Id(), err)
+		}
+		if id == "" {
+			log.Printf("[WARN] server disk (%s): no server has the disk, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		serverid = id
+		d.Set("serverid", serverid)
+	}
+
 	server, err := client.Servers.Details(ctx, serverid)
 	if err != nil {
-		diag.Errorf("server not found: %s", err)
-		d.SetId("")
-		return nil
+		return readError(d, err, "server disk")
 	}
 
 	for _, n := range server.AdditionalDisks {
-		if n.ID == d.Get("id").(string) {
+		if n.ID == d.Id() {
 			d.Set("name", n.Name)
 			d.Set("size", n.SizeInGIB)
 			d.Set("scsiid", n.SCSIID)
 			d.Set("type", n.Type)
+			return nil
 		}
 	}
 
+	log.Printf("[WARN] server disk (%s): the disk no longer exists on server %s, removing from state", d.Id(), serverid)
+	d.SetId("")
 	return nil
 }
 
+// findServerDiskServer returns the ID of the server in the project that has
+// the disk, or "" if there is none.
+func findServerDiskServer(ctx context.Context, client *apiClient, diskID string) (string, error) {
+	servers, err := client.Servers.List(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	for _, s := range *servers {
+		srv, err := client.Servers.Details(ctx, s.ID)
+		if err != nil {
+			if isNotFoundError(err) {
+				continue
+			}
+			return "", err
+		}
+		for _, disk := range srv.AdditionalDisks {
+			if disk.ID == diskID {
+				return srv.ID, nil
+			}
+		}
+	}
+	return "", nil
+}
+
 func resourceGlesysServerDiskUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*glesys.Client)
+	client := m.(*apiClient)
 
 	params := glesys.EditServerDiskParams{
 		ID: d.Get("id").(string),
 	}
 
+	serverID := d.Get("serverid").(string)
+	serverMutexKV.Lock(serverID)
+	defer serverMutexKV.Unlock(serverID)
+
+	if _, err := waitForServerLocked(ctx, serverID, "false", []string{"true"}, "islocked", d.Timeout(schema.TimeoutUpdate), m); err != nil {
+		return diag.Errorf("disk: error while waiting for Server (%s) to be unlocked: %s", serverID, err)
+	}
+
 	// UpdateName has it's own function.
 	if d.HasChange("name") {
 		params.Name = d.Get("name").(string)
@@ -174,19 +354,31 @@ func resourceGlesysServerDiskUpdate(ctx context.Context, d *schema.ResourceData,
 		params.SizeInGIB = d.Get("size").(int)
 		_, err := client.ServerDisks.Reconfigure(ctx, params)
 		if err != nil {
-			return diag.Errorf("Error updating ServerDisk Name: %s", err)
+			return diag.Errorf("Error updating ServerDisk Size: %s", err)
 		}
 	}
 	// If further attributes can be changed in the future, add them here.
 
-	return resourceGlesysServerDiskRead(ctx, d, m)
+	diags := resourceGlesysServerDiskRead(ctx, d, m)
+	if d.HasChange("estimated_cost") {
+		diags = append(diags, costWarning(d, m, "server disk")...)
+	}
+	return diags
 }
 
 func resourceGlesysServerDiskDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*glesys.Client)
+	client := m.(*apiClient)
 
 	diskid := d.Get("id").(string)
 
+	serverID := d.Get("serverid").(string)
+	serverMutexKV.Lock(serverID)
+	defer serverMutexKV.Unlock(serverID)
+
+	if _, err := waitForServerLocked(ctx, serverID, "false", []string{"true"}, "islocked", d.Timeout(schema.TimeoutDelete), m); err != nil {
+		return diag.Errorf("disk: error while waiting for Server (%s) to be unlocked: %s", serverID, err)
+	}
+
 	err := client.ServerDisks.Delete(ctx, diskid)
 	if err != nil {
 		return diag.Errorf("Error deleting ServerDisk (%s): %s", diskid, err)
@@ -1,11 +1,16 @@
 package glesys
 
 import (
+	"context"
 	"fmt"
+	"strings"
 	"testing"
 
+	"github.com/glesys/glesys-go/v8"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 func TestAccServerDiskVMware_basic(t *testing.T) {
@@ -38,3 +43,170 @@ func testAccGlesysServerDiskVMware(name string) string {
 			type     = "silver"
 		} `, name)
 }
+
+func TestResourceGlesysServerDiskCustomizeDiff(t *testing.T) {
+	_, client := newFakeClient(t)
+	ctx := context.Background()
+
+	servers := createFakeServers(t, client, "files:VMware:Falkenberg", "backup:VMware:Falkenberg")
+	srv, other := servers["files"], servers["backup"]
+	disk, err := client.ServerDisks.Create(ctx, glesys.CreateServerDiskParams{
+		Name:      "data",
+		ServerID:  srv.ID,
+		SizeInGIB: 100,
+		Type:      "gold",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range []struct {
+		name        string
+		exists      bool
+		config      map[string]interface{}
+		wantErr     string
+		wantReplace bool
+	}{
+		{
+			name:   "grow",
+			exists: true,
+			config: map[string]interface{}{"size": 200},
+		},
+		{
+			name:    "shrink",
+			exists:  true,
+			config:  map[string]interface{}{"size": 50},
+			wantErr: "size: can't be decreased from 100 to 50 GIB",
+		},
+		{
+			name:        "shrink_by_replace",
+			exists:      true,
+			config:      map[string]interface{}{"size": 50, "allow_shrink_by_replace": true},
+			wantReplace: true,
+		},
+		{
+			name:    "above_limit",
+			exists:  true,
+			config:  map[string]interface{}{"size": 2048},
+			wantErr: "size: 2048 GIB is outside the disk limits of server " + srv.ID + ", valid sizes are 10 to 1024 GIB",
+		},
+		{
+			name:    "type",
+			exists:  true,
+			config:  map[string]interface{}{"size": 100, "type": "silver"},
+			wantErr: "type: the disk can't be changed from gold to silver in place, moving it to another type requires migrating the data. Revert the change",
+		},
+		{
+			name:        "type_with_shrink_by_replace",
+			exists:      true,
+			config:      map[string]interface{}{"size": 50, "type": "silver", "allow_shrink_by_replace": true},
+			wantReplace: true,
+		},
+		{
+			name:        "type_on_another_server",
+			exists:      true,
+			config:      map[string]interface{}{"serverid": other.ID, "size": 100, "type": "silver"},
+			wantReplace: true,
+		},
+		{
+			name:   "type_unset",
+			exists: true,
+			config: map[string]interface{}{"size": 100, "type": nil},
+		},
+		{
+			name:    "new_below_limit",
+			config:  map[string]interface{}{"size": 5},
+			wantErr: "valid sizes are 10 to 1024 GIB",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var state *terraform.InstanceState
+			if tt.exists {
+				state = &terraform.InstanceState{ID: disk.ID, Attributes: map[string]string{
+					"id":                      disk.ID,
+					"serverid":                srv.ID,
+					"name":                    "data",
+					"size":                    "100",
+					"type":                    "gold",
+					"allow_shrink_by_replace": "false",
+				}}
+			}
+			config := mergeConfig(map[string]interface{}{
+				"serverid": srv.ID,
+				"name":     "data",
+				"type":     "gold",
+			}, tt.config)
+
+			diff, err := resourceGlesysServerDisk().Diff(ctx, state, terraform.NewResourceConfigRaw(config), client)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if diff.RequiresNew() != tt.wantReplace {
+				t.Errorf("got replace %t, want %t", diff.RequiresNew(), tt.wantReplace)
+			}
+		})
+	}
+}
+
+func TestResourceGlesysServerDiskRead(t *testing.T) {
+	_, client := newFakeClient(t)
+	ctx := context.Background()
+
+	servers := createFakeServers(t, client, "web:VMware:Falkenberg", "files:VMware:Falkenberg", "bastion:KVM:Falkenberg")
+	srv := servers["files"]
+	disk, err := client.ServerDisks.Create(ctx, glesys.CreateServerDiskParams{
+		Name:      "data",
+		ServerID:  srv.ID,
+		SizeInGIB: 100,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Imported by disk ID only, the server is looked up.
+	r := resourceGlesysServerDisk()
+	d := r.TestResourceData()
+	d.SetId(disk.ID)
+	if _, err := resourceGlesysServerDiskImport(ctx, d, client); err != nil {
+		t.Fatalf("unexpected import error: %s", err)
+	}
+	if diags := resourceGlesysServerDiskRead(ctx, d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if got := d.Get("serverid").(string); got != srv.ID {
+		t.Errorf("got serverid %q, want %q", got, srv.ID)
+	}
+	if got := d.Get("size").(int); got != 100 {
+		t.Errorf("got size %d, want 100", got)
+	}
+
+	unknown := r.TestResourceData()
+	unknown.SetId("00000000-0000-0000-0000-000000000000")
+	if diags := resourceGlesysServerDiskRead(ctx, unknown, client); diags.HasError() || unknown.Id() != "" {
+		t.Errorf("expected unknown disk to be removed from state, got ID %q, %v", unknown.Id(), diags)
+	}
+
+	// Disks deleted outside of Terraform are removed from state.
+	if err := client.ServerDisks.Delete(ctx, disk.ID); err != nil {
+		t.Fatal(err)
+	}
+	if diags := resourceGlesysServerDiskRead(ctx, d, client); diags.HasError() || d.Id() != "" {
+		t.Errorf("expected deleted disk to be removed from state, got ID %q, %v", d.Id(), diags)
+	}
+
+	// So are disks of servers that are gone.
+	gone := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"serverid": "wps999",
+		"size":     100,
+	})
+	gone.SetId(disk.ID)
+	if diags := resourceGlesysServerDiskRead(ctx, gone, client); diags.HasError() || gone.Id() != "" {
+		t.Errorf("expected disk of a missing server to be removed from state, got ID %q, %v", gone.Id(), diags)
+	}
+}
@@ -0,0 +1,135 @@
+package glesys
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceGlesysServerIPAttachment() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceGlesysServerIPAttachmentCreate,
+		ReadContext:   resourceGlesysServerIPAttachmentRead,
+		DeleteContext: resourceGlesysServerIPAttachmentDelete,
+
+		Description: "Attach a reserved `glesys_ip` to a `glesys_server`, in addition to the `ipv4_address` and `ipv6_address` of the server. The IP is removed from the server, but kept in the project, on destroy.",
+
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceGlesysServerIPAttachmentImport,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"address": {
+				Description: "Reserved IP address to attach.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"serverid": {
+				Description: "Server ID to attach the IP to.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"version": {
+				Description: "IP version, `4` or `6`.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// serverIPAttachmentID returns the ID of an attachment, "<serverid>,<address>".
+func serverIPAttachmentID(serverID string, address string) string {
+	return serverID + "," + address
+}
+
+// resourceGlesysServerIPAttachmentImport - import attachments "kvm12345,192.0.2.10"
+func resourceGlesysServerIPAttachmentImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	s := strings.Split(d.Id(), ",")
+	if len(s) != 2 || s[0] == "" || s[1] == "" {
+		return nil, fmt.Errorf("invalid ID %q, expected <serverid>,<address>", d.Id())
+	}
+
+	d.Set("serverid", s[0])
+	d.Set("address", s[1])
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceGlesysServerIPAttachmentCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*apiClient)
+
+	serverID := d.Get("serverid").(string)
+	address := d.Get("address").(string)
+
+	serverMutexKV.Lock(serverID)
+	defer serverMutexKV.Unlock(serverID)
+
+	if _, err := waitForServerLocked(ctx, serverID, "false", []string{"true"}, "islocked", d.Timeout(schema.TimeoutCreate), m); err != nil {
+		return diag.Errorf("ip attachment: error while waiting for Server (%s) to be unlocked: %s", serverID, err)
+	}
+
+	if err := client.addServerIP(ctx, serverID, address); err != nil {
+		return diag.Errorf("Error attaching IP %s to server (%s): %s", address, serverID, err)
+	}
+	d.SetId(serverIPAttachmentID(serverID, address))
+
+	return resourceGlesysServerIPAttachmentRead(ctx, d, m)
+}
+
+func resourceGlesysServerIPAttachmentRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*apiClient)
+
+	serverID := d.Get("serverid").(string)
+	ip, err := client.IPs.Details(ctx, d.Get("address").(string))
+	if err != nil {
+		return readError(d, err, "ip attachment")
+	}
+
+	if ip.ServerID != serverID {
+		log.Printf("[WARN] ip attachment (%s): %s is no longer attached to the server, removing from state", d.Id(), ip.Address)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("address", ip.Address)
+	d.Set("version", ip.Version)
+
+	return nil
+}
+
+func resourceGlesysServerIPAttachmentDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*apiClient)
+
+	serverID := d.Get("serverid").(string)
+	address := d.Get("address").(string)
+
+	serverMutexKV.Lock(serverID)
+	defer serverMutexKV.Unlock(serverID)
+
+	if _, err := waitForServerLocked(ctx, serverID, "false", []string{"true"}, "islocked", d.Timeout(schema.TimeoutDelete), m); err != nil {
+		return diag.Errorf("ip attachment: error while waiting for Server (%s) to be unlocked: %s", serverID, err)
+	}
+
+	if err := client.removeServerIP(ctx, address, false); err != nil {
+		if isNotFoundError(err) {
+			return nil
+		}
+		return diag.Errorf("Error removing IP %s from server (%s): %s", address, serverID, err)
+	}
+
+	d.SetId("")
+	return nil
+}
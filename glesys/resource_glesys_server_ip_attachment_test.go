@@ -0,0 +1,83 @@
+package glesys
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/glesys/glesys-go/v8"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestResourceGlesysServerIPAttachment(t *testing.T) {
+	_, client := newFakeClient(t)
+	ctx := context.Background()
+
+	srv := createFakeServers(t, client, "mail:KVM:Falkenberg")["mail"]
+	free, err := client.IPs.Available(ctx, glesys.AvailableIPsParams{DataCenter: srv.DataCenter, Platform: srv.Platform, Version: 4})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ip, err := client.IPs.Reserve(ctx, (*free)[0].Address)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceGlesysServerIPAttachment().Schema, map[string]interface{}{
+		"serverid": srv.ID,
+		"address":  ip.Address,
+	})
+	if diags := resourceGlesysServerIPAttachmentCreate(ctx, d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if want := srv.ID + "," + ip.Address; d.Id() != want {
+		t.Errorf("got ID %q, want %q", d.Id(), want)
+	}
+	if got := d.Get("version").(int); got != 4 {
+		t.Errorf("got version %d, want 4", got)
+	}
+
+	details, err := client.Servers.Details(ctx, srv.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := len(details.IPList); got != 3 {
+		t.Errorf("got %d IPs on the server, want 3", got)
+	}
+
+	imported := resourceGlesysServerIPAttachment().TestResourceData()
+	imported.SetId(d.Id())
+	if _, err := resourceGlesysServerIPAttachmentImport(ctx, imported, client); err != nil {
+		t.Fatalf("unexpected import error: %s", err)
+	}
+	if diags := resourceGlesysServerIPAttachmentRead(ctx, imported, client); diags.HasError() || imported.Id() == "" {
+		t.Fatalf("expected imported attachment to be read, got %v", diags)
+	}
+	if got := imported.Get("address").(string); got != ip.Address {
+		t.Errorf("got imported address %q, want %q", got, ip.Address)
+	}
+
+	if diags := resourceGlesysServerIPAttachmentDelete(ctx, d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	removed, err := client.IPs.Details(ctx, ip.Address)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if removed.ServerID != "" || removed.Reserved != "yes" {
+		t.Errorf("expected IP to be removed from the server and kept in the project, got server %q reserved %q", removed.ServerID, removed.Reserved)
+	}
+
+	// The attachment is gone once the IP is no longer on the server.
+	if diags := resourceGlesysServerIPAttachmentRead(ctx, imported, client); diags.HasError() || imported.Id() != "" {
+		t.Errorf("expected attachment to be removed from state, got ID %q, %v", imported.Id(), diags)
+	}
+
+	for _, id := range []string{"kvm1", "kvm1,", ",192.0.2.1", "kvm1,192.0.2.1,x"} {
+		d := resourceGlesysServerIPAttachment().TestResourceData()
+		d.SetId(id)
+		if _, err := resourceGlesysServerIPAttachmentImport(ctx, d, client); err == nil || !strings.Contains(err.Error(), "expected <serverid>,<address>") {
+			t.Errorf("import of %q: expected error, got %v", id, err)
+		}
+	}
+}
@@ -0,0 +1,157 @@
+package glesys
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/glesys/glesys-go/v8"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceGlesysServerISO() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceGlesysServerISOCreate,
+		ReadContext:   resourceGlesysServerISORead,
+		UpdateContext: resourceGlesysServerISOUpdate,
+		DeleteContext: resourceGlesysServerISODelete,
+		CustomizeDiff: resourceGlesysServerISOValidatePlatform,
+
+		Description: "Mount an ISO file on a VMware `glesys_server`. The ISO is unmounted on destroy. Use `glesys_server_isos` to list the available ISO files.",
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"isofile": {
+				Description: "ISO file to mount.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"serverid": {
+				Description: "VMware server ID to mount the ISO file on.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+		},
+	}
+}
+
+// resourceGlesysServerISOValidatePlatform rejects servers that can't mount ISO
+// files, when the server already exists.
+func resourceGlesysServerISOValidatePlatform(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	client := m.(*apiClient)
+
+	if !d.HasChange("serverid") || !d.NewValueKnown("serverid") {
+		return nil
+	}
+
+	serverID := d.Get("serverid").(string)
+	srv, err := client.Servers.Details(ctx, serverID)
+	if err != nil {
+		log.Printf("[WARN] unable to fetch server (%s), skipping platform check: %s", serverID, err)
+		return nil
+	}
+	return checkServerISOPlatform(srv)
+}
+
+// checkServerISOPlatform returns an error unless srv can mount ISO files.
+func checkServerISOPlatform(srv *glesys.ServerDetails) error {
+	if srv.Platform != "VMware" {
+		return fmt.Errorf("serverid: ISO files can only be mounted on VMware servers, %s is a %s server", srv.ID, srv.Platform)
+	}
+	return nil
+}
+
+func resourceGlesysServerISOCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*apiClient)
+
+	// The platform isn't checked when planning if the server doesn't exist
+	// yet.
+	serverID := d.Get("serverid").(string)
+	srv, err := client.Servers.Details(ctx, serverID)
+	if err != nil {
+		return diag.Errorf("error retrieving server (%s): %s", serverID, err)
+	}
+	if err := checkServerISOPlatform(srv); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := mountServerISO(ctx, d, serverID, d.Get("isofile").(string), d.Timeout(schema.TimeoutCreate), m); err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(serverID)
+
+	return resourceGlesysServerISORead(ctx, d, m)
+}
+
+func resourceGlesysServerISORead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*apiClient)
+
+	srv, err := client.Servers.Details(ctx, d.Id())
+	if err != nil {
+		return readError(d, err, "server iso")
+	}
+
+	if srv.ISOFile == "" {
+		log.Printf("[WARN] server iso (%s): no ISO file is mounted, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("serverid", srv.ID)
+	d.Set("isofile", srv.ISOFile)
+
+	return nil
+}
+
+func resourceGlesysServerISOUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if err := mountServerISO(ctx, d, d.Id(), d.Get("isofile").(string), d.Timeout(schema.TimeoutUpdate), m); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceGlesysServerISORead(ctx, d, m)
+}
+
+func resourceGlesysServerISODelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if err := mountServerISO(ctx, d, d.Id(), "", d.Timeout(schema.TimeoutDelete), m); err != nil {
+		if isNotFoundError(err) {
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// mountServerISO mounts isoFile on the server once it is unlocked. An empty
+// isoFile unmounts the current ISO file.
+func mountServerISO(ctx context.Context, d *schema.ResourceData, serverID string, isoFile string, timeout time.Duration, m interface{}) error {
+	client := m.(*apiClient)
+
+	serverMutexKV.Lock(serverID)
+	defer serverMutexKV.Unlock(serverID)
+
+	if _, err := waitForServerLocked(ctx, serverID, "false", []string{"true"}, "islocked", timeout, m); err != nil {
+		return fmt.Errorf("server iso: error while waiting for Server (%s) to be unlocked: %w", serverID, err)
+	}
+
+	if _, err := client.Servers.MountISO(ctx, serverID, isoFile); err != nil {
+		if isoFile == "" {
+			return fmt.Errorf("error unmounting ISO file from server (%s): %w", serverID, err)
+		}
+		return fmt.Errorf("error mounting ISO file %s on server (%s): %w", isoFile, serverID, err)
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package glesys
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestResourceGlesysServerISO(t *testing.T) {
+	api, client := newFakeClient(t)
+	ctx := context.Background()
+
+	servers := createFakeServers(t, client, "rescue:VMware:Falkenberg", "web:KVM:Falkenberg")
+	srv := servers["rescue"]
+
+	isos := schema.TestResourceDataRaw(t, dataSourceGlesysServerISOs().Schema, map[string]interface{}{
+		"serverid": srv.ID,
+	})
+	if diags := dataSourceGlesysServerISOsRead(ctx, isos, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	isoFiles := isos.Get("isofiles").([]interface{})
+	if len(isoFiles) != len(fakeISOFiles) {
+		t.Fatalf("got ISO files %v, want %v", isoFiles, fakeISOFiles)
+	}
+	isoFile := isoFiles[1].(string)
+
+	// KVM servers are rejected when planning.
+	r := resourceGlesysServerISO()
+	_, err := r.Diff(ctx, nil, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"serverid": servers["web"].ID,
+		"isofile":  isoFile,
+	}), client)
+	if err == nil || !strings.Contains(err.Error(), "only be mounted on VMware servers") {
+		t.Errorf("expected KVM server to be rejected, got %v", err)
+	}
+	if _, err := r.Diff(ctx, nil, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"serverid": srv.ID,
+		"isofile":  isoFile,
+	}), client); err != nil {
+		t.Errorf("unexpected error planning VMware server: %s", err)
+	}
+
+	// The platform is checked again when creating, since the server may not
+	// have existed when planning.
+	kvm := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"serverid": servers["web"].ID,
+		"isofile":  isoFile,
+	})
+	diags := resourceGlesysServerISOCreate(ctx, kvm, client)
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, "only be mounted on VMware servers") {
+		t.Errorf("expected KVM server to be rejected on create, got %v", diags)
+	}
+	if got := api.callCount("server/mountiso"); got != 0 {
+		t.Errorf("got %d calls to server/mountiso, want none", got)
+	}
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"serverid": srv.ID,
+		"isofile":  isoFile,
+	})
+	if diags := resourceGlesysServerISOCreate(ctx, d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if d.Id() != srv.ID {
+		t.Errorf("got ID %q, want %q", d.Id(), srv.ID)
+	}
+	details, err := client.Servers.Details(ctx, srv.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := details.ISOFile; got != isoFile {
+		t.Errorf("got mounted ISO file %q, want %q", got, isoFile)
+	}
+
+	imported := r.TestResourceData()
+	imported.SetId(srv.ID)
+	if diags := resourceGlesysServerISORead(ctx, imported, client); diags.HasError() || imported.Id() == "" {
+		t.Fatalf("expected imported ISO to be read, got %v", diags)
+	}
+	if got := imported.Get("serverid").(string); got != srv.ID {
+		t.Errorf("got imported serverid %q, want %q", got, srv.ID)
+	}
+
+	if diags := resourceGlesysServerISODelete(ctx, d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	details, err = client.Servers.Details(ctx, srv.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := details.ISOFile; got != "" {
+		t.Errorf("expected ISO file to be unmounted, got %q", got)
+	}
+
+	// The resource is gone once nothing is mounted.
+	if diags := resourceGlesysServerISORead(ctx, imported, client); diags.HasError() || imported.Id() != "" {
+		t.Errorf("expected ISO to be removed from state, got ID %q, %v", imported.Id(), diags)
+	}
+}
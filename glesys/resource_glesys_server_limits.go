@@ -0,0 +1,134 @@
+package glesys
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceGlesysServerLimits() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceGlesysServerLimitsCreate,
+		ReadContext:   resourceGlesysServerLimitsRead,
+		UpdateContext: resourceGlesysServerLimitsUpdate,
+		DeleteContext: resourceGlesysServerLimitsDelete,
+		CustomizeDiff: resourceGlesysServerLimitsPlanReset,
+
+		Description: "Reset the exceeded limits of a `glesys_server`. Limits are reset when the resource is created, when `reset_trigger` changes, and on every apply with `reset_exceeded` set. Destroying the resource leaves the limits as they are.",
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"serverid": {
+				Description: "Server ID.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"reset_exceeded": {
+				Description: "Reset exceeded limits whenever there are any, instead of only when `reset_trigger` changes.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"reset_trigger": {
+				Description: "Arbitrary map of values that, when changed, resets the exceeded limits.",
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"exceeded_limits": {
+				Description: "Names of the limits exceeded since they were last reset.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+// resourceGlesysServerLimitsPlanReset plans an update that resets the limits
+// when reset_exceeded is set and a limit has been exceeded.
+func resourceGlesysServerLimitsPlanReset(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() == "" || !d.Get("reset_exceeded").(bool) {
+		return nil
+	}
+	if len(d.Get("exceeded_limits").([]interface{})) == 0 {
+		return nil
+	}
+	return d.SetNew("exceeded_limits", []string{})
+}
+
+func resourceGlesysServerLimitsCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	serverID := d.Get("serverid").(string)
+	if err := resetExceededServerLimits(ctx, serverID, m); err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(serverID)
+
+	return resourceGlesysServerLimitsRead(ctx, d, m)
+}
+
+func resourceGlesysServerLimitsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*apiClient)
+
+	limits, err := client.serverLimits(ctx, d.Id())
+	if err != nil {
+		return readError(d, err, "server limits")
+	}
+
+	d.Set("serverid", d.Id())
+	d.Set("exceeded_limits", exceededServerLimits(limits))
+
+	return nil
+}
+
+func resourceGlesysServerLimitsUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if d.HasChange("reset_trigger") || d.HasChange("exceeded_limits") {
+		if err := resetExceededServerLimits(ctx, d.Id(), m); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceGlesysServerLimitsRead(ctx, d, m)
+}
+
+func resourceGlesysServerLimitsDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	d.SetId("")
+	return nil
+}
+
+// resetExceededServerLimits resets the limits of the server that have been
+// exceeded.
+func resetExceededServerLimits(ctx context.Context, serverID string, m interface{}) error {
+	client := m.(*apiClient)
+
+	limits, err := client.serverLimits(ctx, serverID)
+	if err != nil {
+		return fmt.Errorf("error retrieving limits of server (%s): %w", serverID, err)
+	}
+	for _, name := range exceededServerLimits(limits) {
+		if err := client.resetServerLimit(ctx, serverID, name); err != nil {
+			return fmt.Errorf("error resetting limit %s of server (%s): %w", name, serverID, err)
+		}
+	}
+	return nil
+}
+
+// exceededServerLimits returns the sorted names of the limits that have been
+// exceeded since they were last reset.
+func exceededServerLimits(limits map[string]serverLimit) []string {
+	exceeded := []string{}
+	for name, l := range limits {
+		if l.Failcount > 0 {
+			exceeded = append(exceeded, name)
+		}
+	}
+	sort.Strings(exceeded)
+	return exceeded
+}
@@ -0,0 +1,91 @@
+package glesys
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestResourceGlesysServerLimits(t *testing.T) {
+	api, client := newFakeClient(t)
+	ctx := context.Background()
+
+	srv := createFakeServers(t, client, "web:KVM:Falkenberg")["web"]
+	api.exceedServerLimit(srv.ID, "numproc")
+
+	status := schema.TestResourceDataRaw(t, dataSourceGlesysServerStatus().Schema, map[string]interface{}{
+		"serverid": srv.ID,
+	})
+	if diags := dataSourceGlesysServerStatusRead(ctx, status, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if got := status.Get("transfer.0.max").(float64); got != 1000 {
+		t.Errorf("got transfer quota %v, want 1000", got)
+	}
+	if got := status.Get("memory.0.max").(float64); got != float64(srv.Memory) {
+		t.Errorf("got memory max %v, want %d", got, srv.Memory)
+	}
+	if got := status.Get("limits.0.name").(string); got != "numproc" {
+		t.Errorf("got first limit %q, want numproc", got)
+	}
+	if got := status.Get("limits.0.failcount").(int); got != 1 {
+		t.Errorf("got numproc failcount %d, want 1", got)
+	}
+
+	r := resourceGlesysServerLimits()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"serverid": srv.ID,
+	})
+	if diags := resourceGlesysServerLimitsCreate(ctx, d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if got := d.Get("exceeded_limits").([]interface{}); len(got) != 0 {
+		t.Errorf("expected limits to be reset, got %v exceeded", got)
+	}
+	if got := api.callCount("server/resetlimit"); got != 1 {
+		t.Errorf("got %d resets, want 1", got)
+	}
+
+	api.exceedServerLimit(srv.ID, "numtcpsock")
+	if diags := resourceGlesysServerLimitsRead(ctx, d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	state := d.State()
+
+	// Exceeded limits are only reset on every apply with reset_exceeded.
+	diff, err := r.Diff(ctx, state, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"serverid": srv.ID,
+	}), client)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !diff.Empty() {
+		t.Errorf("expected no changes without reset_exceeded, got %v", diff.Attributes)
+	}
+
+	d.Set("reset_exceeded", true)
+	state = d.State()
+	config := map[string]interface{}{
+		"serverid":       srv.ID,
+		"reset_exceeded": true,
+	}
+	diff, err = r.Diff(ctx, state, terraform.NewResourceConfigRaw(config), client)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if _, ok := diff.Attributes["exceeded_limits.#"]; !ok {
+		t.Fatalf("expected a reset to be planned, got %v", diff)
+	}
+	state, diags := r.Apply(ctx, state, diff, client)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if got := state.Attributes["exceeded_limits.#"]; got != "0" {
+		t.Errorf("expected limits to be reset, got %s exceeded", got)
+	}
+	if got := api.callCount("server/resetlimit"); got != 2 {
+		t.Errorf("got %d resets, want 2", got)
+	}
+}
@@ -1,12 +1,18 @@
 package glesys
 
 import (
+	"context"
 	"fmt"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/glesys/glesys-go/v8"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 func Test_getTemplate(t *testing.T) {
@@ -188,3 +194,756 @@ func testAccGlesysServerBaseKVMBackupSchedule(name string) string {
 			}
 		} `, name)
 }
+
+func TestResourceGlesysServerValidateArguments(t *testing.T) {
+	api, client := newFakeClient(t)
+
+	kvm := map[string]interface{}{
+		"hostname":   "tf-test",
+		"platform":   "KVM",
+		"datacenter": "Falkenberg",
+		"bandwidth":  100,
+		"cpu":        2,
+		"memory":     2048,
+		"storage":    20,
+		"template":   "Debian 12 (Bookworm)",
+	}
+
+	for _, tt := range []struct {
+		name    string
+		state   map[string]string
+		config  map[string]interface{}
+		wantErr []string
+	}{
+		{
+			name:   "valid",
+			config: kvm,
+		},
+		{
+			name:    "invalid_sizing",
+			config:  mergeConfig(kvm, map[string]interface{}{"cpu": 3, "memory": 5}),
+			wantErr: []string{"cpu: 3 is not available on KVM, valid values are: 1, 2, 4", "memory: 5 is not available on KVM, valid values are: 512, 1024"},
+		},
+		{
+			name:    "invalid_datacenter",
+			config:  mergeConfig(kvm, map[string]interface{}{"datacenter": "Gothenburg"}),
+			wantErr: []string{"datacenter: Gothenburg is not available on KVM"},
+		},
+		{
+			name:   "datacenter_case_insensitive",
+			config: mergeConfig(kvm, map[string]interface{}{"datacenter": "falkenberg"}),
+		},
+		{
+			name:   "template_id",
+			config: mergeConfig(kvm, map[string]interface{}{"template": "fc5d38f7-4c9d-4920-a3a0-3252f71fe2c5"}),
+		},
+		{
+			name:   "template_tag",
+			config: mergeConfig(kvm, map[string]interface{}{"template": "debian-12"}),
+		},
+		{
+			name:    "invalid_template",
+			config:  mergeConfig(kvm, map[string]interface{}{"template": "Debian 12 64-bit"}),
+			wantErr: []string{"template: Debian 12 64-bit is not available on KVM, valid values are: Debian 12 (Bookworm), Ubuntu 24.04 LTS (Noble Numbat)"},
+		},
+		{
+			name:    "vmware_sizing",
+			config:  mergeConfig(kvm, map[string]interface{}{"platform": "VMware", "template": "Debian 12 64-bit", "cpu": 16}),
+			wantErr: []string{"cpu: 16 is not available on VMware"},
+		},
+		{
+			name:    "invalid_platform",
+			config:  mergeConfig(kvm, map[string]interface{}{"platform": "Xen"}),
+			wantErr: []string{"platform: \"Xen\" is not a valid platform"},
+		},
+		{
+			name: "unchanged_values_not_checked",
+			state: map[string]string{
+				"id":         "kvm123",
+				"hostname":   "tf-test",
+				"platform":   "KVM",
+				"datacenter": "Falkenberg",
+				"bandwidth":  "100",
+				"cpu":        "3",
+				"memory":     "2048",
+				"storage":    "20",
+				"template":   "Debian 12 (Bookworm)",
+			},
+			config: mergeConfig(kvm, map[string]interface{}{"cpu": 3, "memory": 4096}),
+		},
+		{
+			name: "changed_values_checked",
+			state: map[string]string{
+				"id":         "kvm123",
+				"hostname":   "tf-test",
+				"platform":   "KVM",
+				"datacenter": "Falkenberg",
+				"bandwidth":  "100",
+				"cpu":        "2",
+				"memory":     "2048",
+				"storage":    "20",
+				"template":   "Debian 12 (Bookworm)",
+			},
+			config:  mergeConfig(kvm, map[string]interface{}{"memory": 3000}),
+			wantErr: []string{"memory: 3000 is not available on KVM"},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var state *terraform.InstanceState
+			if tt.state != nil {
+				state = &terraform.InstanceState{ID: tt.state["id"], Attributes: tt.state}
+			}
+
+			_, err := resourceGlesysServer().Diff(context.Background(), state, terraform.NewResourceConfigRaw(tt.config), client)
+			if len(tt.wantErr) == 0 {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("expected error containing %q", tt.wantErr)
+			}
+			for _, want := range tt.wantErr {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("expected error containing %q, got %q", want, err)
+				}
+			}
+		})
+	}
+
+	if got := api.callCount("server/allowedarguments"); got != 1 {
+		t.Errorf("got %d calls to server/allowedarguments, want it cached after the first", got)
+	}
+}
+
+func TestResourceGlesysServerPowerState(t *testing.T) {
+	api, client := newFakeClient(t)
+
+	config := map[string]interface{}{
+		"hostname":   "tf-test",
+		"platform":   "KVM",
+		"datacenter": "Falkenberg",
+		"bandwidth":  100,
+		"cpu":        2,
+		"memory":     2048,
+		"storage":    20,
+		"template":   "Debian 12 (Bookworm)",
+	}
+
+	for _, tt := range []struct {
+		name        string
+		state       map[string]string
+		config      map[string]interface{}
+		quickReboot bool
+		wantRunning bool
+	}{
+		{
+			name:        "stop",
+			config:      mergeConfig(config, map[string]interface{}{"power_state": "stopped"}),
+			wantRunning: false,
+		},
+		{
+			name:        "reboot",
+			state:       map[string]string{"reboot_trigger.%": "1", "reboot_trigger.cpu": "1"},
+			config:      mergeConfig(config, map[string]interface{}{"reboot_trigger": map[string]interface{}{"cpu": "2"}}),
+			wantRunning: true,
+		},
+		{
+			// A reboot that finishes between two polls doesn't wait for the
+			// whole update timeout.
+			name:        "quick_reboot",
+			state:       map[string]string{"reboot_trigger.%": "1", "reboot_trigger.cpu": "1"},
+			config:      mergeConfig(config, map[string]interface{}{"reboot_trigger": map[string]interface{}{"cpu": "2"}}),
+			quickReboot: true,
+			wantRunning: true,
+		},
+		{
+			name:        "no_reboot_while_stopped",
+			state:       map[string]string{"power_state": "stopped", "reboot_trigger.%": "1", "reboot_trigger.cpu": "1"},
+			config:      mergeConfig(config, map[string]interface{}{"power_state": "stopped", "reboot_trigger": map[string]interface{}{"cpu": "2"}}),
+			wantRunning: false,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			if tt.quickReboot {
+				api.setQuickReboots(true)
+				defer api.setQuickReboots(false)
+
+				defer func(timeout time.Duration) { rebootStartTimeout = timeout }(rebootStartTimeout)
+				rebootStartTimeout = 100 * time.Millisecond
+			}
+
+			srv, err := client.Servers.Create(ctx, glesys.CreateServerParams{
+				Bandwidth:  100,
+				CPU:        2,
+				DataCenter: "Falkenberg",
+				Hostname:   "tf-test",
+				Memory:     2048,
+				Platform:   "KVM",
+				Storage:    20,
+				Template:   "Debian 12 (Bookworm)",
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			attributes := map[string]string{
+				"id":          srv.ID,
+				"hostname":    "tf-test",
+				"platform":    "KVM",
+				"datacenter":  "Falkenberg",
+				"bandwidth":   "100",
+				"cpu":         "2",
+				"memory":      "2048",
+				"storage":     "20",
+				"template":    "Debian 12 (Bookworm)",
+				"power_state": "running",
+			}
+			if tt.state["power_state"] == "stopped" {
+				if err := client.Servers.Stop(ctx, srv.ID, glesys.StopServerParams{Type: "hard"}); err != nil {
+					t.Fatal(err)
+				}
+			}
+			for k, v := range tt.state {
+				attributes[k] = v
+			}
+			state := &terraform.InstanceState{ID: srv.ID, Attributes: attributes}
+
+			r := resourceGlesysServer()
+			diff, err := r.Diff(ctx, state, terraform.NewResourceConfigRaw(tt.config), client)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			state, diags := r.Apply(ctx, state, diff, client)
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+			if api.rebooting(srv.ID) {
+				t.Fatal("apply returned before the server was rebooted")
+			}
+
+			details, err := client.Servers.Details(ctx, srv.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if details.IsRunning != tt.wantRunning {
+				t.Errorf("got server running %v, want %v", details.IsRunning, tt.wantRunning)
+			}
+			want := "stopped"
+			if tt.wantRunning {
+				want = "running"
+			}
+			if got := state.Attributes["power_state"]; got != want {
+				t.Errorf("got power_state %q, want %q", got, want)
+			}
+		})
+	}
+
+	// One stop each for "stop", "reboot", "quick_reboot" and the setup of
+	// "no_reboot_while_stopped", which must not reboot the server.
+	if got := api.callCount("server/stop"); got != 4 {
+		t.Errorf("got %d calls to server/stop, want 4", got)
+	}
+}
+
+func TestResourceGlesysServerImmutableAttributes(t *testing.T) {
+	_, client := newFakeClient(t)
+
+	state := map[string]string{
+		"id":           "kvm123",
+		"hostname":     "tf-test",
+		"platform":     "KVM",
+		"datacenter":   "Falkenberg",
+		"bandwidth":    "100",
+		"cpu":          "2",
+		"memory":       "2048",
+		"storage":      "20",
+		"template":     "Debian 12 (Bookworm)",
+		"publickey":    "ssh-ed25519 AAAA old",
+		"campaigncode": "",
+	}
+	config := map[string]interface{}{
+		"hostname":   "tf-test",
+		"platform":   "KVM",
+		"datacenter": "Falkenberg",
+		"bandwidth":  100,
+		"cpu":        2,
+		"memory":     2048,
+		"storage":    20,
+		"template":   "Debian 12 (Bookworm)",
+		"publickey":  "ssh-ed25519 AAAA old",
+	}
+	user := []interface{}{
+		map[string]interface{}{"username": "admin", "publickeys": []interface{}{"ssh-ed25519 AAAA admin"}},
+	}
+
+	for _, tt := range []struct {
+		name            string
+		config          map[string]interface{}
+		unset           bool
+		imported        bool
+		wantErr         string
+		wantRequiresNew bool
+	}{
+		{
+			name:   "unchanged",
+			config: config,
+		},
+		{
+			name:   "updatable_change",
+			config: mergeConfig(config, map[string]interface{}{"cpu": 4}),
+		},
+		{
+			name:    "error_by_default",
+			config:  mergeConfig(config, map[string]interface{}{"publickey": "ssh-ed25519 AAAA new", "campaigncode": "SUMMER"}),
+			wantErr: "publickey, campaigncode can only be set when the server is created",
+		},
+		{
+			name:            "replace",
+			config:          mergeConfig(config, map[string]interface{}{"publickey": "ssh-ed25519 AAAA new", "on_immutable_change": "replace"}),
+			wantRequiresNew: true,
+		},
+		{
+			// Servers created without the attributes can't get them later.
+			name:    "added_after_create",
+			unset:   true,
+			config:  mergeConfig(config, map[string]interface{}{"user": user, "cloudconfig": "#cloud-config\n"}),
+			wantErr: "user, publickey, cloudconfig can only be set when the server is created",
+		},
+		{
+			// Imported servers have none of the attributes in the state.
+			name:     "imported",
+			unset:    true,
+			imported: true,
+			config:   mergeConfig(config, map[string]interface{}{"user": user, "cloudconfig": "#cloud-config\n"}),
+		},
+		{
+			name:     "imported_not_replaced",
+			unset:    true,
+			imported: true,
+			config:   mergeConfig(config, map[string]interface{}{"user": user, "on_immutable_change": "replace"}),
+		},
+		{
+			name:     "imported_then_changed",
+			imported: true,
+			config:   mergeConfig(config, map[string]interface{}{"publickey": "ssh-ed25519 AAAA new"}),
+			wantErr:  "publickey can only be set when the server is created",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			attributes := map[string]string{}
+			for k, v := range state {
+				attributes[k] = v
+			}
+			if tt.unset {
+				for _, attr := range serverCreationAttributes {
+					delete(attributes, attr)
+				}
+			}
+			s := &terraform.InstanceState{ID: state["id"], Attributes: attributes}
+			if tt.imported {
+				r := resourceGlesysServer()
+				imported, err := r.Importer.StateContext(context.Background(), r.Data(s), client)
+				if err != nil {
+					t.Fatalf("unexpected import error: %s", err)
+				}
+				s = imported[0].State()
+			}
+			diff, err := resourceGlesysServer().Diff(context.Background(), s, terraform.NewResourceConfigRaw(tt.config), client)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got := diff.RequiresNew(); got != tt.wantRequiresNew {
+				t.Errorf("got requires new %v, want %v", got, tt.wantRequiresNew)
+			}
+		})
+	}
+}
+
+func TestResourceGlesysServerTemplateDrift(t *testing.T) {
+	_, client := newFakeClient(t)
+
+	// The server is installed from Ubuntu, reading it with template set to a
+	// tag now on Debian looks like the tag moved after the server was created.
+	srv, err := client.Servers.Create(context.Background(), glesys.CreateServerParams{
+		Bandwidth:  100,
+		CPU:        2,
+		DataCenter: "Falkenberg",
+		Hostname:   "tf-test",
+		Memory:     2048,
+		Platform:   "KVM",
+		Storage:    20,
+		Template:   "ubuntu-lts",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range []struct {
+		name         string
+		template     string
+		drift        string
+		wantTemplate string
+		wantWarning  bool
+	}{
+		{
+			name:         "tag_unchanged",
+			template:     "ubuntu-lts",
+			drift:        "warn",
+			wantTemplate: "ubuntu-lts",
+		},
+		{
+			name:         "tag_moved_warn",
+			template:     "debian-12",
+			drift:        "warn",
+			wantTemplate: "debian-12",
+			wantWarning:  true,
+		},
+		{
+			name:         "tag_moved_ignore",
+			template:     "debian-12",
+			drift:        "ignore",
+			wantTemplate: "debian-12",
+		},
+		{
+			name:         "name",
+			template:     "Ubuntu 24.04 LTS (Noble Numbat)",
+			drift:        "warn",
+			wantTemplate: "Ubuntu 24.04 LTS (Noble Numbat)",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			d := resourceGlesysServer().TestResourceData()
+			d.SetId(srv.ID)
+			d.Set("template", tt.template)
+			d.Set("template_drift", tt.drift)
+
+			diags := resourceGlesysServerRead(context.Background(), d, client)
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+			if got := len(diags) > 0; got != tt.wantWarning {
+				t.Errorf("got warning %v, want %v: %v", got, tt.wantWarning, diags)
+			}
+			if got := d.Get("template").(string); got != tt.wantTemplate {
+				t.Errorf("got template %q, want %q", got, tt.wantTemplate)
+			}
+			if got := d.Get("template_id").(string); got != "fc5d38f7-4c9d-4920-a3a0-3252f71fe2c5" {
+				t.Errorf("got template_id %q, want the installed template", got)
+			}
+			if got := d.Get("template_name").(string); got != "Ubuntu 24.04 LTS (Noble Numbat)" {
+				t.Errorf("got template_name %q, want the installed template", got)
+			}
+		})
+	}
+}
+
+func TestResourceGlesysServerNetworkAdapterErrors(t *testing.T) {
+	api, client := newFakeClient(t)
+
+	srv, err := client.Servers.Create(context.Background(), glesys.CreateServerParams{
+		Bandwidth:  100,
+		CPU:        2,
+		DataCenter: "Falkenberg",
+		Hostname:   "tf-test",
+		Memory:     2048,
+		Platform:   "KVM",
+		Storage:    20,
+		Template:   "ubuntu-lts",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("read", func(t *testing.T) {
+		d := resourceGlesysServer().TestResourceData()
+		d.SetId(srv.ID)
+
+		api.failNext("server/networkadapters", fakeBadRequest("Network adapters of %s could not be listed", srv.ID))
+		diags := resourceGlesysServerRead(context.Background(), d, client)
+		if !diags.HasError() {
+			t.Fatal("expected an error when the network adapters can't be listed")
+		}
+		if d.Id() != srv.ID {
+			t.Errorf("server was removed from state")
+		}
+	})
+
+	t.Run("set_network", func(t *testing.T) {
+		d := resourceGlesysServer().TestResourceData()
+		d.SetId(srv.ID)
+		d.Set("primary_networkadapter_network", "vl123")
+
+		api.failNext("server/networkadapters", fakeBadRequest("Network adapters of %s could not be listed", srv.ID))
+		if err := setServerNetworkAdapter(context.Background(), d, client); err == nil {
+			t.Fatal("expected an error when the network adapters can't be listed")
+		}
+		if got := api.callCount("networkadapter/edit"); got != 0 {
+			t.Errorf("got %d calls to networkadapter/edit, want 0", got)
+		}
+	})
+}
+
+func TestResourceGlesysServerSwapIP(t *testing.T) {
+	api, client := newFakeClient(t)
+
+	for _, tt := range []struct {
+		name         string
+		keepip       bool
+		addFails     bool
+		wantReserved string
+	}{
+		{name: "release", keepip: false, wantReserved: "no"},
+		{name: "keepip", keepip: true, wantReserved: "yes"},
+		{name: "add_fails", addFails: true},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			srv := createFakeServers(t, client, tt.name+":KVM:Falkenberg")[tt.name]
+			oldIPv4, oldIPv6 := serverAddresses(srv)
+
+			free, err := client.IPs.Available(ctx, glesys.AvailableIPsParams{DataCenter: "Falkenberg", Platform: "KVM", Version: 4})
+			if err != nil {
+				t.Fatal(err)
+			}
+			newIPv4, err := client.IPs.Reserve(ctx, (*free)[0].Address)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			state := &terraform.InstanceState{ID: srv.ID, Attributes: map[string]string{
+				"id":           srv.ID,
+				"hostname":     tt.name,
+				"platform":     "KVM",
+				"datacenter":   "Falkenberg",
+				"bandwidth":    "100",
+				"cpu":          "2",
+				"memory":       "2048",
+				"storage":      "20",
+				"template":     "debian-12",
+				"ipv4_address": oldIPv4,
+				"ipv6_address": oldIPv6,
+				"keepip":       strconv.FormatBool(tt.keepip),
+			}}
+			config := map[string]interface{}{
+				"hostname":     tt.name,
+				"platform":     "KVM",
+				"datacenter":   "Falkenberg",
+				"bandwidth":    100,
+				"cpu":          2,
+				"memory":       2048,
+				"storage":      20,
+				"template":     "debian-12",
+				"ipv4_address": newIPv4.Address,
+				"keepip":       tt.keepip,
+			}
+
+			r := resourceGlesysServer()
+			diff, err := r.Diff(ctx, state, terraform.NewResourceConfigRaw(config), client)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if diff.RequiresNew() {
+				t.Fatal("expected the address to be changed in place")
+			}
+			if tt.addFails {
+				api.failNext("ip/add", fakeBadRequest("IP %s can't be added", newIPv4.Address))
+			}
+			state, diags := r.Apply(ctx, state, diff, client)
+			if tt.addFails {
+				if !diags.HasError() {
+					t.Fatal("expected the apply to fail")
+				}
+				// The server keeps its old address.
+				if got := state.Attributes["ipv4_address"]; got != oldIPv4 {
+					t.Errorf("got ipv4_address %q, want the old address %q", got, oldIPv4)
+				}
+				details, err := client.Servers.Details(ctx, srv.ID)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if got, _ := serverAddresses(details); got != oldIPv4 {
+					t.Errorf("got server address %q, want the old address %q", got, oldIPv4)
+				}
+				return
+			}
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+
+			if got := state.Attributes["ipv4_address"]; got != newIPv4.Address {
+				t.Errorf("got ipv4_address %q, want %q", got, newIPv4.Address)
+			}
+			if got := state.Attributes["ipv6_address"]; got != oldIPv6 {
+				t.Errorf("got ipv6_address %q, want it unchanged %q", got, oldIPv6)
+			}
+
+			old, err := client.IPs.Details(ctx, oldIPv4)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if old.ServerID != "" || old.Reserved != tt.wantReserved {
+				t.Errorf("got old address on server %q reserved %q, want removed and reserved %q", old.ServerID, old.Reserved, tt.wantReserved)
+			}
+		})
+	}
+}
+
+func TestResourceGlesysServerClone(t *testing.T) {
+	_, client := newFakeClient(t)
+	ctx := context.Background()
+
+	source, err := client.Servers.Create(ctx, glesys.CreateServerParams{
+		Backup: []glesys.ServerBackupSchedule{
+			{Frequency: "weekly", Numberofimagestokeep: 4},
+			{Frequency: "daily", Numberofimagestokeep: 7},
+		},
+		Bandwidth:  100,
+		CPU:        2,
+		DataCenter: "Falkenberg",
+		Hostname:   "production",
+		Memory:     2048,
+		Platform:   "KVM",
+		Storage:    20,
+		Template:   "debian-12",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	backups := schema.TestResourceDataRaw(t, dataSourceGlesysServerBackups().Schema, map[string]interface{}{
+		"serverid": source.ID,
+	})
+	if diags := dataSourceGlesysServerBackupsRead(ctx, backups, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if got, want := backups.Get("backups.#").(int), 2; got != want {
+		t.Fatalf("got %d backups, want %d", got, want)
+	}
+	latest := backups.Get("backups.0.id").(string)
+	if want := source.ID + "-daily"; latest != want {
+		t.Errorf("got latest backup %q, want %q", latest, want)
+	}
+
+	config := map[string]interface{}{
+		"hostname":         "test",
+		"platform":         "KVM",
+		"datacenter":       "Falkenberg",
+		"bandwidth":        100,
+		"cpu":              2,
+		"memory":           2048,
+		"storage":          20,
+		"source_server_id": source.ID,
+		"source_backup_id": latest,
+	}
+
+	r := resourceGlesysServer()
+	if diags := r.Validate(terraform.NewResourceConfigRaw(map[string]interface{}{
+		"hostname":         "test",
+		"bandwidth":        100,
+		"cpu":              2,
+		"memory":           2048,
+		"storage":          20,
+		"template":         "debian-12",
+		"source_server_id": source.ID,
+	})); !diags.HasError() {
+		t.Error("expected template and source_server_id to conflict")
+	}
+
+	d := schema.TestResourceDataRaw(t, r.Schema, config)
+	if diags := resourceGlesysServerCreate(ctx, d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if d.Id() == source.ID {
+		t.Fatal("expected a new server")
+	}
+	if got := d.Get("template_id").(string); got != source.InitialTemplate.ID {
+		t.Errorf("got template_id %q, want the template of the source %q", got, source.InitialTemplate.ID)
+	}
+
+	// The clone has no template, which must not plan a replacement.
+	diff, err := r.Diff(ctx, d.State(), terraform.NewResourceConfigRaw(config), client)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if diff.RequiresNew() {
+		t.Errorf("expected no replacement of the clone, got %v", diff.Attributes)
+	}
+
+	d = schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"hostname":         "test",
+		"platform":         "KVM",
+		"datacenter":       "Falkenberg",
+		"bandwidth":        100,
+		"cpu":              2,
+		"memory":           2048,
+		"storage":          20,
+		"source_server_id": source.ID,
+		"source_backup_id": source.ID + "-monthly",
+	})
+	if diags := resourceGlesysServerCreate(ctx, d, client); !diags.HasError() || !strings.Contains(diags[0].Summary, "does not exist") {
+		t.Errorf("expected missing backup to fail, got %v", diags)
+	}
+}
+
+func TestResourceGlesysServerPreventShrink(t *testing.T) {
+	_, client := newFakeClient(t)
+	ctx := context.Background()
+
+	srv := createFakeServers(t, client, "db:KVM:Falkenberg")["db"]
+	state := &terraform.InstanceState{ID: srv.ID, Attributes: map[string]string{
+		"id":                      srv.ID,
+		"hostname":                "db",
+		"platform":                "KVM",
+		"datacenter":              "Falkenberg",
+		"bandwidth":               "100",
+		"cpu":                     "2",
+		"memory":                  "2048",
+		"storage":                 "20",
+		"template":                "debian-12",
+		"allow_shrink_by_replace": "false",
+	}}
+	config := func(storage int, allowShrink bool) *terraform.ResourceConfig {
+		return terraform.NewResourceConfigRaw(map[string]interface{}{
+			"hostname":                "db",
+			"platform":                "KVM",
+			"datacenter":              "Falkenberg",
+			"bandwidth":               100,
+			"cpu":                     2,
+			"memory":                  2048,
+			"storage":                 storage,
+			"template":                "debian-12",
+			"allow_shrink_by_replace": allowShrink,
+		})
+	}
+
+	r := resourceGlesysServer()
+	if _, err := r.Diff(ctx, state, config(10, false), client); err == nil || !strings.Contains(err.Error(), "storage: can't be decreased from 20 to 10 GIB") {
+		t.Errorf("expected shrinking storage to fail, got %v", err)
+	}
+
+	diff, err := r.Diff(ctx, state, config(10, true), client)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !diff.RequiresNew() {
+		t.Error("expected the server to be replaced")
+	}
+
+	diff, err = r.Diff(ctx, state, config(40, false), client)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if diff.RequiresNew() {
+		t.Error("expected storage to grow in place")
+	}
+}
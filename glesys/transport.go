@@ -0,0 +1,174 @@
+package glesys
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/glesys/glesys-go/v8"
+	"github.com/hashicorp/go-retryablehttp"
+)
+
+const (
+	defaultMaxRetries   = 5
+	defaultRetryMaxWait = 30 * time.Second
+	retryMinWait        = 1 * time.Second
+)
+
+// readOnlyActions are the API actions that never change anything, and are
+// safe to send again if the first attempt failed. The GleSYS API uses POST for
+// most of these, so the HTTP method alone isn't enough.
+var readOnlyActions = map[string]bool{
+	"allowedarguments":   true,
+	"connectiondetails":  true,
+	"costs":              true,
+	"details":            true,
+	"estimatedcost":      true,
+	"instancedetails":    true,
+	"limits":             true,
+	"list":               true,
+	"listfree":           true,
+	"listinstances":      true,
+	"listiso":            true,
+	"listown":            true,
+	"listplans":          true,
+	"listrecords":        true,
+	"listsegments":       true,
+	"networkadapters":    true,
+	"overview":           true,
+	"previewcloudconfig": true,
+	"status":             true,
+	"templates":          true,
+}
+
+// defaultClientMu serializes the swaps of http.DefaultClient in
+// newGlesysClient.
+var defaultClientMu sync.Mutex
+
+// newGlesysClient creates a glesys-go client sending its requests with
+// httpClient.
+//
+// glesys-go has no option for the HTTP client and uses the http.DefaultClient
+// it finds when the client is created, so the process-global
+// http.DefaultClient is swapped for the duration of the call. Anything else
+// using http.DefaultClient meanwhile would get httpClient, so this must only be
+// called while configuring the provider, which Terraform does before any
+// resource or data source runs, and nothing in the provider may use
+// http.DefaultClient itself. This can go once glesys-go accepts an HTTP client.
+func newGlesysClient(project, apiKey, userAgent string, httpClient *http.Client) *glesys.Client {
+	defaultClientMu.Lock()
+	defer defaultClientMu.Unlock()
+
+	defaultClient := http.DefaultClient
+	http.DefaultClient = httpClient
+	defer func() { http.DefaultClient = defaultClient }()
+
+	return glesys.NewClient(project, apiKey, userAgent)
+}
+
+// newRetryClient returns an HTTP client retrying API calls that are rate
+// limited or failed on the way to the API. Calls that may have reached the
+// API are only retried for read-only actions, since most other actions can't
+// safely be repeated.
+func newRetryClient(next http.RoundTripper, maxRetries int, maxWait time.Duration) *http.Client {
+	client := retryablehttp.NewClient()
+	client.HTTPClient = &http.Client{Transport: next}
+	client.Logger = log.Default()
+	client.RetryMax = maxRetries
+	client.RetryWaitMin = min(retryMinWait, maxWait)
+	client.RetryWaitMax = maxWait
+	client.CheckRetry = checkRetry
+	client.Backoff = func(minWait, maxWait time.Duration, attemptNum int, resp *http.Response) time.Duration {
+		// Retry-After from the API is respected, but never longer than maxWait.
+		return min(retryablehttp.DefaultBackoff(minWait, maxWait, attemptNum, resp), maxWait)
+	}
+	// Return the last response from the API when giving up, so that glesys-go
+	// can report the error from the API.
+	client.ErrorHandler = retryablehttp.PassthroughErrorHandler
+
+	// CheckRetry only gets the context of the request, so whether the action
+	// is read-only is recorded there before the request is sent.
+	return &http.Client{Transport: &readOnlyTransport{next: client.StandardClient().Transport}}
+}
+
+type readOnlyKey struct{}
+
+// readOnlyTransport marks the context of requests for read-only actions, for
+// checkRetry.
+type readOnlyTransport struct {
+	next http.RoundTripper
+}
+
+func (t *readOnlyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if isIdempotentRequest(req) {
+		req = req.WithContext(context.WithValue(req.Context(), readOnlyKey{}, true))
+	}
+	return t.next.RoundTrip(req)
+}
+
+// checkRetry retries on 429 Too Many Requests and 503 Service Unavailable,
+// which the API returns before doing any work, and when the connection to the
+// API couldn't be established. Read-only actions are also retried on 502 Bad
+// Gateway, 504 Gateway Timeout and other transport errors.
+func checkRetry(ctx context.Context, resp *http.Response, err error) (bool, error) {
+	if ctx.Err() != nil {
+		return false, ctx.Err()
+	}
+	readOnly, _ := ctx.Value(readOnlyKey{}).(bool)
+
+	if err != nil {
+		var opErr *net.OpError
+		return readOnly || errors.As(err, &opErr) && opErr.Op == "dial", nil
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+		return true, nil
+	case http.StatusBadGateway, http.StatusGatewayTimeout:
+		return readOnly, nil
+	}
+	return false, nil
+}
+
+// isIdempotentRequest reports whether req can be sent again without risk of
+// doing something twice.
+func isIdempotentRequest(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+
+	parts := strings.Split(strings.Trim(req.URL.Path, "/"), "/")
+	if len(parts) < 2 {
+		return false
+	}
+	return readOnlyActions[parts[1]]
+}
+
+// limitTransport caps the number of API requests in flight at the same time.
+type limitTransport struct {
+	next http.RoundTripper
+	sem  chan struct{}
+}
+
+func newLimitTransport(next http.RoundTripper, limit int) *limitTransport {
+	return &limitTransport{next: next, sem: make(chan struct{}, limit)}
+}
+
+func (t *limitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	select {
+	case t.sem <- struct{}{}:
+	case <-req.Context().Done():
+		return nil, req.Context().Err()
+	}
+	// glesys-go doesn't always close the response body, so the slot is
+	// released as soon as the response headers are received.
+	defer func() { <-t.sem }()
+
+	return t.next.RoundTrip(req)
+}
@@ -0,0 +1,273 @@
+package glesys
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/glesys/glesys-go/v8"
+)
+
+// testFlakyAPI returns a server answering the first failures requests with
+// status, and then with an empty successful response.
+func testFlakyAPI(failures int32, status int, header http.Header) (*httptest.Server, *int32) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) <= failures {
+			for k, v := range header {
+				w.Header()[k] = v
+			}
+			w.WriteHeader(status)
+			w.Write([]byte(`{"response":{"status":{"code":0,"text":"temporary failure"}}}`))
+			return
+		}
+		w.Write([]byte(`{"response":{"status":{"code":200,"text":"OK"}}}`))
+	}))
+	return srv, &calls
+}
+
+func testRetryClient(t *testing.T, url string, maxRetries int) *glesys.Client {
+	config := Config{
+		UserID:       "cl12345",
+		Token:        "fake-token",
+		APIEndpoint:  url,
+		MaxRetries:   maxRetries,
+		RetryMaxWait: 10 * time.Millisecond,
+	}
+	client, err := config.Client()
+	if err != nil {
+		t.Fatalf("could not create client: %s", err)
+	}
+	return client.Client
+}
+
+func TestRetryTransport(t *testing.T) {
+	for _, tt := range []struct {
+		name      string
+		failures  int32
+		status    int
+		call      func(ctx context.Context, c *glesys.Client) error
+		wantCalls int32
+		wantErr   bool
+	}{
+		{
+			name:     "get_retried_on_503",
+			failures: 2,
+			status:   http.StatusServiceUnavailable,
+			call: func(ctx context.Context, c *glesys.Client) error {
+				_, err := c.Servers.Details(ctx, "kvm123")
+				return err
+			},
+			wantCalls: 3,
+		},
+		{
+			name:     "read_only_post_retried_on_502",
+			failures: 1,
+			status:   http.StatusBadGateway,
+			call: func(ctx context.Context, c *glesys.Client) error {
+				_, err := c.IPs.Details(ctx, "192.0.2.1")
+				return err
+			},
+			wantCalls: 2,
+		},
+		{
+			name:     "create_retried_on_503",
+			failures: 1,
+			status:   http.StatusServiceUnavailable,
+			call: func(ctx context.Context, c *glesys.Client) error {
+				_, err := c.Networks.Create(ctx, glesys.CreateNetworkParams{DataCenter: "Falkenberg"})
+				return err
+			},
+			wantCalls: 2,
+		},
+		{
+			name:     "create_not_retried_on_502",
+			failures: 1,
+			status:   http.StatusBadGateway,
+			call: func(ctx context.Context, c *glesys.Client) error {
+				_, err := c.Networks.Create(ctx, glesys.CreateNetworkParams{DataCenter: "Falkenberg"})
+				return err
+			},
+			wantCalls: 1,
+			wantErr:   true,
+		},
+		{
+			name:     "create_retried_on_429",
+			failures: 2,
+			status:   http.StatusTooManyRequests,
+			call: func(ctx context.Context, c *glesys.Client) error {
+				_, err := c.Networks.Create(ctx, glesys.CreateNetworkParams{DataCenter: "Falkenberg"})
+				return err
+			},
+			wantCalls: 3,
+		},
+		{
+			name:     "retries_capped",
+			failures: 10,
+			status:   http.StatusServiceUnavailable,
+			call: func(ctx context.Context, c *glesys.Client) error {
+				_, err := c.Servers.List(ctx)
+				return err
+			},
+			wantCalls: 4,
+			wantErr:   true,
+		},
+		{
+			name:     "client_error_not_retried",
+			failures: 1,
+			status:   http.StatusBadRequest,
+			call: func(ctx context.Context, c *glesys.Client) error {
+				_, err := c.Servers.List(ctx)
+				return err
+			},
+			wantCalls: 1,
+			wantErr:   true,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, calls := testFlakyAPI(tt.failures, tt.status, nil)
+			defer srv.Close()
+
+			err := tt.call(context.Background(), testRetryClient(t, srv.URL, 3))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("got error: %v, want error %v", err, tt.wantErr)
+			}
+			if got := atomic.LoadInt32(calls); got != tt.wantCalls {
+				t.Errorf("got %d calls, want %d", got, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestRetryTransport_retryAfter(t *testing.T) {
+	srv, calls := testFlakyAPI(1, http.StatusTooManyRequests, http.Header{"Retry-After": {"1"}})
+	defer srv.Close()
+
+	client := testRetryClient(t, srv.URL, 3)
+
+	start := time.Now()
+	if _, err := client.Servers.List(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// Retry-After is capped by retry_max_wait.
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("expected Retry-After to be capped, waited %s", elapsed)
+	}
+	if got := atomic.LoadInt32(calls); got != 2 {
+		t.Errorf("got %d calls, want 2", got)
+	}
+}
+
+func Test_checkRetry(t *testing.T) {
+	dialErr := &url.Error{Op: "Post", URL: "https://api.glesys.com/server/create", Err: &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}}
+	readErr := &url.Error{Op: "Post", URL: "https://api.glesys.com/server/create", Err: &net.OpError{Op: "read", Net: "tcp", Err: errors.New("connection reset by peer")}}
+
+	for _, tt := range []struct {
+		name     string
+		readOnly bool
+		status   int
+		err      error
+		want     bool
+	}{
+		{name: "rate_limited", status: http.StatusTooManyRequests, want: true},
+		{name: "unavailable", status: http.StatusServiceUnavailable, want: true},
+		{name: "bad_gateway", status: http.StatusBadGateway, want: false},
+		{name: "gateway_timeout", status: http.StatusGatewayTimeout, want: false},
+		{name: "ok", status: http.StatusOK, want: false},
+		{name: "dial_error", err: dialErr, want: true},
+		{name: "read_error", err: readErr, want: false},
+		{name: "read_only_rate_limited", readOnly: true, status: http.StatusTooManyRequests, want: true},
+		{name: "read_only_bad_gateway", readOnly: true, status: http.StatusBadGateway, want: true},
+		{name: "read_only_gateway_timeout", readOnly: true, status: http.StatusGatewayTimeout, want: true},
+		{name: "read_only_server_error", readOnly: true, status: http.StatusInternalServerError, want: false},
+		{name: "read_only_ok", readOnly: true, status: http.StatusOK, want: false},
+		{name: "read_only_read_error", readOnly: true, err: readErr, want: true},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.readOnly {
+				ctx = context.WithValue(ctx, readOnlyKey{}, true)
+			}
+			var resp *http.Response
+			if tt.err == nil {
+				resp = &http.Response{StatusCode: tt.status}
+			}
+			got, err := checkRetry(ctx, resp, tt.err)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_isIdempotentRequest(t *testing.T) {
+	for path, want := range map[string]bool{
+		"/server/details/serverid/kvm1/includestate/yes": true,
+		"/ip/details":         true,
+		"/domain/listrecords": true,
+		"/server/create":      false,
+		"/ip/take":            false,
+		"/serverdisk/delete":  false,
+	} {
+		req := httptest.NewRequest(http.MethodPost, "https://api.glesys.com"+path, strings.NewReader("{}"))
+		if got := isIdempotentRequest(req); got != want {
+			t.Errorf("%s: got %v, want %v", path, got, want)
+		}
+	}
+}
+
+func TestLimitTransport(t *testing.T) {
+	var running, maxRunning int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&running, 1)
+		for {
+			current := atomic.LoadInt32(&maxRunning)
+			if n <= current || atomic.CompareAndSwapInt32(&maxRunning, current, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		atomic.AddInt32(&running, -1)
+		w.Write([]byte(`{"response":{"status":{"code":200,"text":"OK"}}}`))
+	}))
+	defer srv.Close()
+
+	config := Config{
+		UserID:                "cl12345",
+		Token:                 "fake-token",
+		APIEndpoint:           srv.URL,
+		MaxConcurrentRequests: 2,
+	}
+	client, err := config.Client()
+	if err != nil {
+		t.Fatalf("could not create client: %s", err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.Servers.List(context.Background()); err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := atomic.LoadInt32(&maxRunning); got > 2 {
+		t.Errorf("got %d concurrent requests, want at most 2", got)
+	}
+}